@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/eljosho/dms-manager/internal/checkpoints"
+	"github.com/eljosho/dms-manager/internal/tui"
+	"github.com/eljosho/dms-manager/pkg/dms"
+	"github.com/spf13/cobra"
+)
+
+// addCDCFlags registers the flags positioning CDC when tasks start
+func addCDCFlags(cmd *cobra.Command) {
+	cmd.Flags().String("cdc-start-time", "", "Start CDC from the changes made at this time (RFC 3339, cdc tasks only)")
+	cmd.Flags().String("cdc-start-position", "", "Start CDC from a native source position, checkpoint or timestamp (cdc tasks only)")
+	cmd.Flags().String("cdc-stop-position", "", "Stop CDC at server_time:<time> or commit_time:<time>, e.g. commit_time:2024-05-01T12:00:00")
+	cmd.Flags().String("from-checkpoint", "", "Start CDC from the checkpoint saved with 'checkpoint save': latest or a version number")
+	cmd.Flags().BoolP("yes", "y", false, "Skip the confirmation when starting from or stopping at a CDC position")
+}
+
+// cdcPlan holds the CDC options each task is started with
+type cdcPlan struct {
+	options     map[string]dms.CDCOptions
+	checkpoints map[string]checkpoints.Checkpoint // with --from-checkpoint
+	tasks       map[string]dms.Task
+}
+
+// forTask returns the CDC options of a task
+func (p cdcPlan) forTask(arn string) dms.CDCOptions {
+	return p.options[arn]
+}
+
+// isZero reports whether no task has CDC options
+func (p cdcPlan) isZero() bool {
+	for _, opts := range p.options {
+		if !opts.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// cdcOptions builds the CDC options of each task from the command flags,
+// exiting on the first problem
+func cdcOptions(ctx context.Context, cmd *cobra.Command, client *dms.Client, taskARNs []string) cdcPlan {
+	var opts dms.CDCOptions
+	if s, _ := cmd.Flags().GetString("cdc-start-time"); s != "" {
+		t, err := dms.ParseCDCStartTime(s)
+		if err != nil {
+			exitWithError(err)
+		}
+		opts.StartTime = &t
+	}
+	opts.StartPosition, _ = cmd.Flags().GetString("cdc-start-position")
+	if s, _ := cmd.Flags().GetString("cdc-stop-position"); s != "" {
+		stop, err := dms.ParseCDCStopPosition(s)
+		if err != nil {
+			exitWithError(err)
+		}
+		opts.StopPosition = stop
+	}
+	from, _ := cmd.Flags().GetString("from-checkpoint")
+	if from != "" && (opts.StartTime != nil || opts.StartPosition != "") {
+		exitWithError(fmt.Errorf("--from-checkpoint can't be combined with a CDC start time or position"))
+	}
+	return planCDC(ctx, client, taskARNs, opts, from)
+}
+
+// planCDC gives every task the same CDC options, starting each from its saved
+// checkpoint if from is set, and checks them against the migration type of
+// the task
+func planCDC(ctx context.Context, client *dms.Client, taskARNs []string, opts dms.CDCOptions, from string) cdcPlan {
+	plan := cdcPlan{options: make(map[string]dms.CDCOptions, len(taskARNs))}
+	if opts.IsZero() && from == "" {
+		return plan
+	}
+
+	tasks, err := tasksByARN(ctx, client, taskARNs)
+	if err != nil {
+		exitWithError(err)
+	}
+	plan.tasks = tasks
+
+	if from != "" {
+		plan.checkpoints, err = savedCheckpoints(taskARNs, tasks, from)
+		if err != nil {
+			exitWithError(err)
+		}
+	}
+
+	for _, arn := range taskARNs {
+		taskOpts := opts
+		if cp, ok := plan.checkpoints[arn]; ok {
+			taskOpts.StartPosition = cp.RecoveryCheckpoint
+		}
+		if err := taskOpts.Validate(tasks[arn]); err != nil {
+			exitWithError(err)
+		}
+		plan.options[arn] = taskOpts
+	}
+	return plan
+}
+
+// savedCheckpoints looks up the saved checkpoint of each task; version is
+// "latest" or a version number
+func savedCheckpoints(taskARNs []string, tasks map[string]dms.Task, version string) (map[string]checkpoints.Checkpoint, error) {
+	n := 0
+	if version != "latest" {
+		var err error
+		if n, err = strconv.Atoi(version); err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid checkpoint version %q (use latest or a version number)", version)
+		}
+	}
+
+	store, err := checkpoints.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]checkpoints.Checkpoint, len(taskARNs))
+	for _, arn := range taskARNs {
+		cp, ok := store.Get(arn, n)
+		if !ok {
+			if n == 0 {
+				return nil, fmt.Errorf("no checkpoint saved for %s", tasks[arn].Name)
+			}
+			return nil, fmt.Errorf("no checkpoint version %d saved for %s", n, tasks[arn].Name)
+		}
+		found[arn] = cp
+	}
+	return found, nil
+}
+
+// confirmCDC lists the tasks about to be started with CDC options and asks
+// for confirmation unless --yes is set. It does nothing without CDC options.
+func confirmCDC(cmd *cobra.Command, verb string, taskARNs []string, plan cdcPlan) {
+	if plan.isZero() {
+		return
+	}
+
+	fmt.Printf("%s %d task(s):\n\n", verb, len(taskARNs))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+		tui.CLIHeaderStyle.Render("TASK"),
+		tui.CLIHeaderStyle.Render("TYPE"),
+		tui.CLIHeaderStyle.Render("STATUS"),
+		tui.CLIHeaderStyle.Render("CDC"))
+	for _, arn := range taskARNs {
+		task := plan.tasks[arn]
+		cdc := plan.options[arn].Describe()
+		if cp, ok := plan.checkpoints[arn]; ok {
+			cdc = fmt.Sprintf("CDC from checkpoint v%d saved %s", cp.Version, cp.SavedAt.Local().Format("2006-01-02 15:04"))
+			if stop := plan.options[arn].StopPosition; stop != "" {
+				cdc += " until " + stop
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			tui.CLIPrimaryStyle.Render(task.Name),
+			tui.CLIMutedStyle.Render(task.MigrationType),
+			getListStatusStyle(task.Status).Render(task.Status),
+			tui.CLIValueStyle.Render(cdc))
+	}
+	w.Flush()
+	fmt.Println()
+
+	if yes, _ := cmd.Flags().GetBool("yes"); yes {
+		return
+	}
+	if !confirm("Proceed?") {
+		exitWithError(fmt.Errorf("aborted"))
+	}
+	fmt.Println()
+}
+
+// tasksByARN looks up the given tasks with a single listing
+func tasksByARN(ctx context.Context, client *dms.Client, taskARNs []string) (map[string]dms.Task, error) {
+	all, err := client.ListTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tasks := make(map[string]dms.Task, len(taskARNs))
+	for _, task := range all {
+		tasks[task.ARN] = task
+	}
+	for _, arn := range taskARNs {
+		if _, ok := tasks[arn]; !ok {
+			return nil, fmt.Errorf("task not found: %s", arn)
+		}
+	}
+	return tasks, nil
+}
+
+// confirm asks a yes/no question on stdin and reports whether it was answered
+// with yes
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N] ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/databasemigrationservice/types"
+	"github.com/eljosho/dms-manager/internal/checkpoints"
+	"github.com/eljosho/dms-manager/internal/tui"
+	"github.com/eljosho/dms-manager/pkg/dms"
+	"github.com/spf13/cobra"
+)
+
+// checkpointWidth is where long recovery checkpoints are cut in listings
+const checkpointWidth = 48
+
+var (
+	checkpointNote     string
+	checkpointVersion  string
+	checkpointFull     bool
+	checkpointStopAt   string
+	checkpointAssumeOK bool
+)
+
+var checkpointCmd = &cobra.Command{
+	Use:   "checkpoint",
+	Short: "Save and restore CDC recovery checkpoints",
+	Long: `Save the recovery checkpoints of CDC tasks to a local store, and resume tasks
+from a saved checkpoint, e.g. after a reload that went wrong.
+
+Each save adds a new version per task, with the time it was taken and an
+optional note. The store is dms-manager/checkpoints.json in the user config
+directory, or the file named by $DMS_MANAGER_CHECKPOINTS.
+
+Examples:
+  dms-manager checkpoint save "prod-*" --note "before reload"
+  dms-manager checkpoint list prod-orders
+  dms-manager checkpoint restore prod-orders --version 3
+  dms-manager resume "prod-*" --from-checkpoint latest`,
+}
+
+var checkpointSaveCmd = &cobra.Command{
+	Use:   "save [task-arn-or-name...]",
+	Short: "Save the current recovery checkpoints of tasks",
+	Long: `Save the current recovery checkpoints of one or more tasks. Tasks that have
+no checkpoint yet, because CDC has not run, are skipped.
+
+Wildcards are supported for task names (e.g. "prod-*", "*-database").`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runCheckpointSave,
+}
+
+var checkpointListCmd = &cobra.Command{
+	Use:   "list [task-arn-or-name...]",
+	Short: "List saved checkpoints",
+	Long: `List the saved checkpoints of the given tasks, or of all tasks, newest first.
+
+Listing all checkpoints only reads the local store and needs no AWS access.`,
+	Run: runCheckpointList,
+}
+
+var checkpointRestoreCmd = &cobra.Command{
+	Use:   "restore [task-arn-or-name...]",
+	Short: "Resume tasks from a saved checkpoint",
+	Long: `Resume one or more tasks from a saved checkpoint, the latest one unless
+--version is given. This is the same as resume --from-checkpoint.
+
+The tasks and checkpoints are listed for confirmation first.`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runCheckpointRestore,
+}
+
+func init() {
+	checkpointSaveCmd.Flags().StringVarP(&checkpointNote, "note", "m", "", "Note to save with the checkpoints")
+	checkpointListCmd.Flags().BoolVar(&checkpointFull, "full", false, "Show checkpoints in full instead of cutting them short")
+	checkpointRestoreCmd.Flags().StringVar(&checkpointVersion, "version", "latest", "Checkpoint version to restore: latest or a version number")
+	checkpointRestoreCmd.Flags().StringVar(&checkpointStopAt, "cdc-stop-position", "", "Stop CDC at server_time:<time> or commit_time:<time>")
+	checkpointRestoreCmd.Flags().BoolVarP(&checkpointAssumeOK, "yes", "y", false, "Skip the confirmation")
+	checkpointCmd.AddCommand(checkpointSaveCmd, checkpointListCmd, checkpointRestoreCmd)
+	rootCmd.AddCommand(checkpointCmd)
+}
+
+func runCheckpointSave(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	client, err := dms.NewClient(ctx, GetProfile(), GetRegion())
+	if err != nil {
+		exitWithError(fmt.Errorf("failed to create DMS client: %w", err))
+	}
+
+	taskARNs, err := resolveTaskARNs(ctx, client, args)
+	if err != nil {
+		exitWithError(err)
+	}
+	if len(taskARNs) == 0 {
+		exitWithError(fmt.Errorf("no valid tasks found"))
+	}
+
+	tasks, err := tasksByARN(ctx, client, taskARNs)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	store, err := checkpoints.Open()
+	if err != nil {
+		exitWithError(err)
+	}
+
+	now := time.Now().UTC()
+	saved := 0
+	for _, arn := range taskARNs {
+		task := tasks[arn]
+		if task.RecoveryCheckpoint == "" {
+			fmt.Printf("- %s: %s\n", task.Name, tui.CLIWarningStyle.Render("no recovery checkpoint yet, skipped"))
+			continue
+		}
+		cp := store.Add(checkpoints.Checkpoint{
+			TaskARN:            task.ARN,
+			TaskName:           task.Name,
+			RecoveryCheckpoint: task.RecoveryCheckpoint,
+			CdcStartPosition:   task.CdcStartPosition,
+			Status:             task.Status,
+			SavedAt:            now,
+			Note:               checkpointNote,
+		})
+		saved++
+		fmt.Printf("✓ %s: saved checkpoint v%d\n", task.Name, cp.Version)
+	}
+
+	if saved == 0 {
+		exitWithError(fmt.Errorf("none of the tasks has a recovery checkpoint"))
+	}
+	if err := store.Save(); err != nil {
+		exitWithError(err)
+	}
+	fmt.Printf("\nSaved %d checkpoint(s) to %s\n", saved, store.Path())
+}
+
+func runCheckpointList(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	store, err := checkpoints.Open()
+	if err != nil {
+		exitWithError(err)
+	}
+
+	var taskARNs []string
+	if len(args) > 0 {
+		client, err := dms.NewClient(ctx, GetProfile(), GetRegion())
+		if err != nil {
+			exitWithError(fmt.Errorf("failed to create DMS client: %w", err))
+		}
+		if taskARNs, err = resolveTaskARNs(ctx, client, args); err != nil {
+			exitWithError(err)
+		}
+		if len(taskARNs) == 0 {
+			exitWithError(fmt.Errorf("no valid tasks found"))
+		}
+	}
+
+	list := store.List(taskARNs...)
+	if len(list) == 0 {
+		fmt.Println(tui.CLIWarningStyle.Render("No saved checkpoints."))
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	headers := []string{"TASK", "VERSION", "SAVED", "STATUS", "NOTE", "CHECKPOINT"}
+	var coloredHeaders []string
+	var coloredSeps []string
+	for _, h := range headers {
+		coloredHeaders = append(coloredHeaders, tui.CLIHeaderStyle.Render(h))
+		coloredSeps = append(coloredSeps, tui.CLIMutedStyle.Render(strings.Repeat("─", len(h))))
+	}
+	fmt.Fprintln(w, strings.Join(coloredHeaders, "\t"))
+	fmt.Fprintln(w, strings.Join(coloredSeps, "\t"))
+
+	for _, cp := range list {
+		note := cp.Note
+		if note == "" {
+			note = "-"
+		}
+		checkpoint := cp.RecoveryCheckpoint
+		if !checkpointFull {
+			checkpoint = truncateCheckpoint(checkpoint)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			tui.CLIPrimaryStyle.Render(cp.TaskName),
+			tui.CLINumberStyle.Render(fmt.Sprintf("v%d", cp.Version)),
+			tui.CLIValueStyle.Render(cp.SavedAt.Local().Format("2006-01-02 15:04:05")),
+			getListStatusStyle(cp.Status).Render(cp.Status),
+			tui.CLIValueStyle.Render(note),
+			tui.CLIMutedStyle.Render(checkpoint),
+		)
+	}
+	w.Flush()
+
+	fmt.Printf("\n%s %s\n", tui.CLILabelStyle.Render("Total checkpoints:"), tui.CLINumberStyle.Render(fmt.Sprintf("%d", len(list))))
+}
+
+func runCheckpointRestore(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	client, err := dms.NewClient(ctx, GetProfile(), GetRegion())
+	if err != nil {
+		exitWithError(fmt.Errorf("failed to create DMS client: %w", err))
+	}
+
+	taskARNs, err := resolveTaskARNs(ctx, client, args)
+	if err != nil {
+		exitWithError(err)
+	}
+	if len(taskARNs) == 0 {
+		exitWithError(fmt.Errorf("no valid tasks found"))
+	}
+
+	var opts dms.CDCOptions
+	if checkpointStopAt != "" {
+		if opts.StopPosition, err = dms.ParseCDCStopPosition(checkpointStopAt); err != nil {
+			exitWithError(err)
+		}
+	}
+	plan := planCDC(ctx, client, taskARNs, opts, checkpointVersion)
+	confirmCDC(cmd, "Restoring", taskARNs, plan)
+
+	fmt.Printf("Resuming %d task(s) from their checkpoints...\n\n", len(taskARNs))
+
+	results := client.StartTasksAt(ctx, taskARNs, types.StartReplicationTaskTypeValueResumeProcessing, plan.forTask)
+
+	successCount := 0
+	for _, result := range results {
+		if result.Success {
+			successCount++
+			fmt.Printf("✓ %s: %s\n", getTaskNameFromARN(result.TaskARN), result.Message)
+		} else {
+			fmt.Printf("✗ %s: %s\n", getTaskNameFromARN(result.TaskARN), result.Message)
+		}
+	}
+
+	fmt.Printf("\nSuccessfully restored %d out of %d tasks\n", successCount, len(taskARNs))
+}
+
+// truncateCheckpoint cuts a recovery checkpoint short for listings
+func truncateCheckpoint(checkpoint string) string {
+	r := []rune(checkpoint)
+	if len(r) <= checkpointWidth {
+		return checkpoint
+	}
+	return string(r[:checkpointWidth-1]) + "…"
+}
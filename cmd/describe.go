@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/eljosho/dms-manager/internal/tui"
@@ -25,6 +26,10 @@ You can specify multiple task ARNs or task names as arguments.`,
 
 func init() {
 	describeCmd.Flags().Bool("tables", false, "Show table statistics")
+	describeCmd.Flags().Bool("settings", false, "Show the full task settings as JSON")
+	describeCmd.Flags().StringSlice("schema", nil, "Only show the statistics of tables in these schemas (implies --tables)")
+	describeCmd.Flags().StringSlice("table", nil, "Only show the statistics of these tables (implies --tables)")
+	describeCmd.Flags().StringSlice("table-state", nil, `Only show the statistics of tables in these states, e.g. "Table error" (implies --tables)`)
 	rootCmd.AddCommand(describeCmd)
 }
 
@@ -67,8 +72,23 @@ func runDescribe(cmd *cobra.Command, args []string) {
 		exitWithError(fmt.Errorf("no valid tasks found"))
 	}
 
+	// Endpoint names are a nicety; fall back to ARNs if they can't be listed
+	endpoints, err := client.ListEndpoints(ctx)
+	if err != nil {
+		fmt.Printf("%s could not resolve endpoint names: %v\n", tui.CLIWarningStyle.Render("Warning:"), err)
+	}
+	endpointsByARN := dms.EndpointsByARN(endpoints)
+
 	// Describe each task
 	showTables, _ := cmd.Flags().GetBool("tables")
+	showSettings, _ := cmd.Flags().GetBool("settings")
+
+	// Table filters are applied by DMS
+	var filter dms.TableStatsFilter
+	filter.Schemas, _ = cmd.Flags().GetStringSlice("schema")
+	filter.Tables, _ = cmd.Flags().GetStringSlice("table")
+	filter.States, _ = cmd.Flags().GetStringSlice("table-state")
+	showTables = showTables || !filter.IsZero()
 
 	for i, arn := range taskARNs {
 		if i > 0 {
@@ -81,20 +101,22 @@ func runDescribe(cmd *cobra.Command, args []string) {
 			continue
 		}
 
-		printTaskDetails(task)
+		printTaskDetails(task, endpointsByARN, showSettings)
 
 		if showTables {
-			stats, err := client.GetTableStatistics(ctx, arn)
+			stats, err := client.GetTableStatisticsFiltered(ctx, arn, filter)
 			if err != nil {
 				fmt.Printf("\n%s %v\n", tui.CLIErrorStyle.Render("Error fetching table statistics:"), err)
 			} else {
-				printTableStatistics(stats)
+				printTableStatistics(stats, !filter.IsZero())
 			}
 		}
 	}
 }
 
-func printTaskDetails(task *dms.Task) {
+func printTaskDetails(task *dms.Task, endpoints map[string]dms.Endpoint, showSettings bool) {
+	now := time.Now()
+
 	// Task header
 	fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("Task:"), tui.CLIPrimaryStyle.Render(task.Name))
 	fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("ARN:"), tui.CLIMutedStyle.Render(task.ARN))
@@ -104,8 +126,13 @@ func printTaskDetails(task *dms.Task) {
 	// Endpoints section
 	fmt.Println("\n" + tui.CLIHighlightStyle.Render("Endpoints:"))
 	fmt.Printf("  %s %s\n", tui.CLILabelStyle.Render("Replication Instance:"), tui.CLIMutedStyle.Render(task.ReplicationInstanceARN))
-	fmt.Printf("  %s %s\n", tui.CLILabelStyle.Render("Source:"), tui.CLIMutedStyle.Render(task.SourceEndpointARN))
-	fmt.Printf("  %s %s\n", tui.CLILabelStyle.Render("Target:"), tui.CLIMutedStyle.Render(task.TargetEndpointARN))
+	if task.IsMoving() {
+		fmt.Printf("  %s %s %s\n", tui.CLILabelStyle.Render("Moving To:"),
+			tui.CLIWarningStyle.Render(getInstanceNameFromARN(task.TargetReplicationInstanceARN)),
+			tui.CLIMutedStyle.Render(task.TargetReplicationInstanceARN))
+	}
+	fmt.Printf("  %s %s\n", tui.CLILabelStyle.Render("Source:"), formatTaskEndpoint(task.SourceEndpointARN, endpoints))
+	fmt.Printf("  %s %s\n", tui.CLILabelStyle.Render("Target:"), formatTaskEndpoint(task.TargetEndpointARN, endpoints))
 
 	// Timestamps
 	if task.CreatedAt != nil {
@@ -113,13 +140,43 @@ func printTaskDetails(task *dms.Task) {
 	}
 
 	if task.StartedAt != nil {
-		fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("Started At:"), tui.CLIValueStyle.Render(task.StartedAt.Format("2006-01-02 15:04:05")))
+		fmt.Printf("%s %s%s\n", tui.CLILabelStyle.Render("Started At:"), tui.CLIValueStyle.Render(task.StartedAt.Format("2006-01-02 15:04:05")), formatRunDuration(*task, now))
+	}
+
+	if task.StoppedAt != nil {
+		fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("Stopped At:"), tui.CLIValueStyle.Render(task.StoppedAt.Format("2006-01-02 15:04:05")))
+	}
+
+	if stats := task.ReplicationTaskStats; stats != nil {
+		if stats.FreshStartDate != nil {
+			fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("Fresh Start At:"), tui.CLIValueStyle.Render(stats.FreshStartDate.Format("2006-01-02 15:04:05")))
+		}
+		if stats.FullLoadStartDate != nil {
+			fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("Full Load:"), formatFullLoadWindow(*stats, now))
+		}
+	}
+
+	if task.StopReason != "" {
+		fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("Stop Reason:"), tui.CLIWarningStyle.Render(task.StopReason))
 	}
 
 	if task.LastFailureMessage != "" {
 		fmt.Printf("\n%s %s\n", tui.CLIErrorStyle.Render("Last Failure:"), task.LastFailureMessage)
 	}
 
+	if task.RecoveryCheckpoint != "" || task.CdcStartPosition != "" || task.CdcStopPosition != "" {
+		fmt.Println("\n" + tui.CLIHighlightStyle.Render("CDC:"))
+		for _, field := range []struct{ label, value string }{
+			{"Recovery Checkpoint:", task.RecoveryCheckpoint},
+			{"Start Position:", task.CdcStartPosition},
+			{"Stop Position:", task.CdcStopPosition},
+		} {
+			if field.value != "" {
+				fmt.Printf("  %s %s\n", tui.CLILabelStyle.Render(field.label), tui.CLIValueStyle.Render(field.value))
+			}
+		}
+	}
+
 	if task.ReplicationTaskStats != nil {
 		stats := task.ReplicationTaskStats
 		fmt.Println("\n" + tui.CLIHighlightStyle.Render("Statistics:"))
@@ -132,52 +189,178 @@ func printTaskDetails(task *dms.Task) {
 		if stats.ElapsedTimeMillis > 0 {
 			fmt.Printf("  %s %s\n", tui.CLILabelStyle.Render("Elapsed Time:"), tui.CLIValueStyle.Render(dms.FormatElapsedTime(stats.ElapsedTimeMillis)))
 		}
+	}
 
-		if stats.StopReason != "" {
-			fmt.Printf("  %s %s\n", tui.CLILabelStyle.Render("Stop Reason:"), tui.CLIWarningStyle.Render(stats.StopReason))
+	if task.Settings != "" {
+		fmt.Println("\n" + tui.CLIHighlightStyle.Render("Settings:"))
+		summary, err := dms.SettingsSummary(task.Settings)
+		switch {
+		case err != nil:
+			fmt.Printf("  %s\n", tui.CLIErrorStyle.Render(err.Error()))
+		case len(summary) == 0:
+			fmt.Printf("  %s\n", tui.CLIMutedStyle.Render("DMS defaults"))
+		default:
+			fmt.Printf("  %s\n", tui.CLIValueStyle.Render(strings.Join(summary, ", ")))
 		}
+		if showSettings {
+			fmt.Println(indentJSON(task.Settings))
+		}
+	}
+
+	if task.TaskData != "" {
+		fmt.Println("\n" + tui.CLIHighlightStyle.Render("Task Data:"))
+		fmt.Println(indentJSON(task.TaskData))
+	}
+}
+
+// formatRunDuration renders how long a task ran or has been running, e.g.
+// " (running for 2h 5m)", or nothing if that is unknown
+func formatRunDuration(task dms.Task, now time.Time) string {
+	d, ok := task.RunDuration(now)
+	if !ok {
+		return ""
+	}
+	if task.StoppedAt != nil && task.StoppedAt.After(*task.StartedAt) {
+		return tui.CLIMutedStyle.Render(" (ran " + dms.FormatDuration(d) + ")")
+	}
+	if strings.EqualFold(task.Status, "running") {
+		return tui.CLIMutedStyle.Render(" (running for " + dms.FormatDuration(d) + ")")
+	}
+	return ""
+}
+
+// formatFullLoadWindow renders when the full load started and finished and
+// how long it took, or how long it has been running
+func formatFullLoadWindow(stats dms.TaskStats, now time.Time) string {
+	out := tui.CLIValueStyle.Render(stats.FullLoadStartDate.Format("2006-01-02 15:04:05"))
+	d, _ := stats.FullLoadDuration(now)
+	if stats.FullLoadFinishDate == nil {
+		return out + tui.CLIMutedStyle.Render(" (running for "+dms.FormatDuration(d)+")")
+	}
+	return out + " → " + tui.CLIValueStyle.Render(stats.FullLoadFinishDate.Format("2006-01-02 15:04:05")) +
+		tui.CLIMutedStyle.Render(" (took "+dms.FormatDuration(d)+")")
+}
+
+// indentJSON pretty-prints a JSON document indented under a section, or
+// returns it as is if it doesn't parse
+func indentJSON(doc string) string {
+	pretty, err := dms.PrettyJSON(doc)
+	if err != nil {
+		pretty = doc
+	}
+	return "  " + strings.ReplaceAll(pretty, "\n", "\n  ")
+}
+
+// formatTaskEndpoint renders an endpoint ARN as the endpoint's identifier and
+// engine followed by the ARN, or just the ARN if the endpoint is unknown
+func formatTaskEndpoint(arn string, endpoints map[string]dms.Endpoint) string {
+	ep, ok := endpoints[arn]
+	if !ok {
+		return tui.CLIMutedStyle.Render(arn)
 	}
+	return tui.CLIValueStyle.Render(ep.Label()) + " " + tui.CLIMutedStyle.Render(arn)
 }
 
-func printTableStatistics(stats []dms.TableStatistic) {
+func printTableStatistics(stats []dms.TableStatistic, filtered bool) {
 	if len(stats) == 0 {
-		fmt.Println("\n" + tui.CLIWarningStyle.Render("Table Statistics: None"))
+		if filtered {
+			fmt.Println("\n" + tui.CLIWarningStyle.Render("Table Statistics: no tables match the filter"))
+		} else {
+			fmt.Println("\n" + tui.CLIWarningStyle.Render("Table Statistics: None"))
+		}
 		return
 	}
 
 	fmt.Println("\n" + tui.CLIHighlightStyle.Render("Table Statistics:"))
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 
-	// Colored headers
-	headers := []string{"SCHEMA", "TABLE", "INSERTS", "UPDATES", "DELETES", "DDLS", "ROWS", "STATE"}
+	// Colored headers with a separator line under each
+	headers := []string{"SCHEMA", "TABLE", "STATE", "ROWS", "ERRORS", "INSERTS", "UPDATES", "DELETES", "DDLS", "APPLIED I/U/D", "FULL LOAD", "VALIDATION"}
 	var coloredHeaders []string
+	var coloredSeps []string
 	for _, h := range headers {
 		coloredHeaders = append(coloredHeaders, tui.CLIHeaderStyle.Render(h))
+		coloredSeps = append(coloredSeps, tui.CLIMutedStyle.Render(strings.Repeat("─", len([]rune(h)))))
 	}
 	fmt.Fprintln(w, "  "+strings.Join(coloredHeaders, "\t"))
-
-	// Separator line
-	separators := []string{"──────", "─────", "───────", "───────", "───────", "────", "────", "─────"}
-	var coloredSeps []string
-	for _, s := range separators {
-		coloredSeps = append(coloredSeps, tui.CLIMutedStyle.Render(s))
-	}
 	fmt.Fprintln(w, "  "+strings.Join(coloredSeps, "\t"))
 
+	now := time.Now()
 	for _, s := range stats {
-		stateStyle := getValidationStateStyle(s.ValidationState)
-		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
 			tui.CLIValueStyle.Render(s.SchemaName),
 			tui.CLIPrimaryStyle.Render(s.TableName),
+			getTableStateStyle(s.TableState).Render(valueOrDash(s.TableState)),
+			tui.CLINumberStyle.Render(fmt.Sprintf("%d", s.FullLoadRows)),
+			formatTableErrors(s),
 			tui.CLINumberStyle.Render(fmt.Sprintf("%d", s.Inserts)),
 			tui.CLINumberStyle.Render(fmt.Sprintf("%d", s.Updates)),
 			tui.CLINumberStyle.Render(fmt.Sprintf("%d", s.Deletes)),
 			tui.CLINumberStyle.Render(fmt.Sprintf("%d", s.Ddls)),
-			tui.CLINumberStyle.Render(fmt.Sprintf("%d", s.FullLoadRows)),
-			stateStyle.Render(s.ValidationState),
+			tui.CLINumberStyle.Render(fmt.Sprintf("%d/%d/%d", s.AppliedInserts, s.AppliedUpdates, s.AppliedDeletes)),
+			formatTableFullLoad(s, now),
+			formatTableValidation(s),
 		)
 	}
 	w.Flush()
+
+	// Validation details explain failed and suspended validations
+	var details []dms.TableStatistic
+	for _, s := range stats {
+		if s.ValidationStateDetails != "" {
+			details = append(details, s)
+		}
+	}
+	if len(details) > 0 {
+		fmt.Println("\n" + tui.CLIHighlightStyle.Render("Validation Details:"))
+		for _, s := range details {
+			fmt.Printf("  %s %s\n", tui.CLILabelStyle.Render(s.SchemaName+"."+s.TableName+":"), tui.CLIValueStyle.Render(s.ValidationStateDetails))
+		}
+	}
+}
+
+// formatTableErrors renders the rows that failed to load, followed by the
+// rows that failed a conditional check if any, e.g. "3 (+1 check)"
+func formatTableErrors(s dms.TableStatistic) string {
+	out := getDescribeErrorCountStyle(s.FullLoadErrorRows).Render(fmt.Sprintf("%d", s.FullLoadErrorRows))
+	if s.FullLoadCondtnlChkFailedRows > 0 {
+		out += tui.CLIErrorStyle.Render(fmt.Sprintf(" (+%d check)", s.FullLoadCondtnlChkFailedRows))
+	}
+	return out
+}
+
+// formatTableFullLoad renders how long the full load of a table took or has
+// been running, marking tables that were reloaded
+func formatTableFullLoad(s dms.TableStatistic, now time.Time) string {
+	d, ok := s.FullLoadDuration(now)
+	if !ok {
+		return tui.CLIMutedStyle.Render("-")
+	}
+	out := tui.CLIValueStyle.Render(dms.FormatDuration(d))
+	if s.FullLoadEndTime == nil {
+		out = tui.CLIWarningStyle.Render("running " + dms.FormatDuration(d))
+	}
+	if s.FullLoadReloaded {
+		out += tui.CLIMutedStyle.Render(" (reloaded)")
+	}
+	return out
+}
+
+// formatTableValidation renders the validation state of a table followed by
+// its non-zero record counters, e.g. "Pending records (12 pending, 1 failed)"
+func formatTableValidation(s dms.TableStatistic) string {
+	out := getValidationStateStyle(s.ValidationState).Render(valueOrDash(s.ValidationState))
+	if counts := s.ValidationCounts(); counts != "" {
+		out += " " + tui.CLIMutedStyle.Render("("+counts+")")
+	}
+	return out
+}
+
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
 }
 
 // getDescribeStatusStyle returns the appropriate style for a task status
@@ -193,13 +376,27 @@ func getDescribeStatusStyle(status string) lipgloss.Style {
 }
 
 // getDescribeErrorCountStyle returns red for non-zero error counts
-func getDescribeErrorCountStyle(count int32) lipgloss.Style {
+func getDescribeErrorCountStyle[T int32 | int64](count T) lipgloss.Style {
 	if count > 0 {
 		return tui.CLIErrorStyle
 	}
 	return tui.CLINumberStyle
 }
 
+// getTableStateStyle returns color based on the load state of a table
+func getTableStateStyle(state string) lipgloss.Style {
+	switch strings.ToLower(state) {
+	case "table completed":
+		return tui.CLISuccessStyle
+	case "table error", "table cancelled":
+		return tui.CLIErrorStyle
+	case "":
+		return tui.CLIMutedStyle
+	default:
+		return tui.CLIWarningStyle
+	}
+}
+
 // getValidationStateStyle returns color based on validation state
 func getValidationStateStyle(state string) lipgloss.Style {
 	switch strings.ToLower(state) {
@@ -0,0 +1,347 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eljosho/dms-manager/internal/tui"
+	"github.com/eljosho/dms-manager/pkg/dms"
+	"github.com/spf13/cobra"
+)
+
+var (
+	endpointsTestInstances []string
+	endpointsTestTimeout   time.Duration
+	endpointsTestInterval  time.Duration
+)
+
+var endpointsCmd = &cobra.Command{
+	Use:   "endpoints",
+	Short: "Manage DMS source and target endpoints",
+	Long: `List and describe DMS endpoints and test their connectivity from
+replication instances.
+
+Endpoints and instances can be given by identifier or ARN.
+
+Examples:
+  dms-manager endpoints list
+  dms-manager endpoints describe orders-source
+  dms-manager endpoints test orders-source
+  dms-manager endpoints test orders-source --instance prod-instance`,
+}
+
+var endpointsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all endpoints",
+	Long:  `List all source and target endpoints with their engine, server and status.`,
+	Args:  cobra.NoArgs,
+	Run:   runEndpointsList,
+}
+
+var endpointsDescribeCmd = &cobra.Command{
+	Use:   "describe [endpoint...]",
+	Short: "Get detailed information about endpoints",
+	Long: `Get detailed information about one or more endpoints, including the tasks
+using each and the results of past connection tests.`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runEndpointsDescribe,
+}
+
+var endpointsTestCmd = &cobra.Command{
+	Use:   "test [endpoint]",
+	Short: "Test the connection to an endpoint",
+	Long: `Test the connection from one or more replication instances to an endpoint
+and wait for the results.
+
+Without --instance, the endpoint is tested from every instance hosting a task
+that uses it. The command exits with an error if any test fails.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runEndpointsTest,
+}
+
+func init() {
+	endpointsTestCmd.Flags().StringSliceVarP(&endpointsTestInstances, "instance", "i", nil, "Replication instance to test from (repeatable)")
+	endpointsTestCmd.Flags().DurationVar(&endpointsTestTimeout, "timeout", 5*time.Minute, "Give up on tests that have not finished after this long")
+	endpointsTestCmd.Flags().DurationVar(&endpointsTestInterval, "interval", 5*time.Second, "Time between polls for test results")
+	endpointsCmd.AddCommand(endpointsListCmd, endpointsDescribeCmd, endpointsTestCmd)
+	rootCmd.AddCommand(endpointsCmd)
+}
+
+func runEndpointsList(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	client, err := dms.NewClient(ctx, GetProfile(), GetRegion())
+	if err != nil {
+		exitWithError(fmt.Errorf("failed to create DMS client: %w", err))
+	}
+
+	fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("Region:"), tui.CLIPrimaryStyle.Render(client.GetRegion()))
+	if client.GetProfile() != "" {
+		fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("Profile:"), tui.CLISecondaryStyle.Render(client.GetProfile()))
+	}
+	fmt.Println()
+
+	endpoints, err := client.ListEndpoints(ctx)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if len(endpoints) == 0 {
+		fmt.Println(tui.CLIWarningStyle.Render("No DMS endpoints found."))
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	headers := []string{"IDENTIFIER", "TYPE", "ENGINE", "SERVER", "DATABASE", "STATUS"}
+	var coloredHeaders []string
+	var coloredSeps []string
+	for _, h := range headers {
+		coloredHeaders = append(coloredHeaders, tui.CLIHeaderStyle.Render(h))
+		coloredSeps = append(coloredSeps, tui.CLIMutedStyle.Render(strings.Repeat("─", len(h))))
+	}
+	fmt.Fprintln(w, strings.Join(coloredHeaders, "\t"))
+	fmt.Fprintln(w, strings.Join(coloredSeps, "\t"))
+
+	for _, ep := range endpoints {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			tui.CLIPrimaryStyle.Render(ep.Identifier),
+			tui.CLIValueStyle.Render(strings.ToLower(ep.Type)),
+			tui.CLIValueStyle.Render(ep.Engine),
+			tui.CLIValueStyle.Render(formatEndpointServer(ep)),
+			tui.CLIValueStyle.Render(ep.DatabaseName),
+			getEndpointStatusStyle(ep.Status).Render(ep.Status),
+		)
+	}
+	w.Flush()
+
+	fmt.Printf("\n%s %s\n", tui.CLILabelStyle.Render("Total endpoints:"), tui.CLINumberStyle.Render(fmt.Sprintf("%d", len(endpoints))))
+}
+
+func runEndpointsDescribe(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	client, err := dms.NewClient(ctx, GetProfile(), GetRegion())
+	if err != nil {
+		exitWithError(fmt.Errorf("failed to create DMS client: %w", err))
+	}
+
+	tasks, err := client.ListTasks(ctx)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	for i, arg := range args {
+		if i > 0 {
+			fmt.Println("\n" + tui.CLIMutedStyle.Render(strings.Repeat("─", 80)))
+		}
+
+		ep, err := client.DescribeEndpoint(ctx, arg)
+		if err != nil {
+			fmt.Printf("%s %s: %v\n", tui.CLIErrorStyle.Render("Error describing endpoint"), arg, err)
+			continue
+		}
+
+		connections, err := client.DescribeConnections(ctx, "", ep.ARN)
+		if err != nil {
+			fmt.Printf("%s %v\n", tui.CLIWarningStyle.Render("Warning: could not fetch connection tests:"), err)
+		}
+
+		printEndpointDetails(ep, endpointTasks(tasks, ep.ARN), connections)
+	}
+}
+
+func runEndpointsTest(cmd *cobra.Command, args []string) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	client, err := dms.NewClient(ctx, GetProfile(), GetRegion())
+	if err != nil {
+		exitWithError(fmt.Errorf("failed to create DMS client: %w", err))
+	}
+
+	ep, err := client.DescribeEndpoint(ctx, args[0])
+	if err != nil {
+		exitWithError(err)
+	}
+
+	instanceARNs, err := endpointTestInstances(ctx, client, ep)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	fmt.Printf("Testing %s from %d instance(s)...\n\n", ep.Label(), len(instanceARNs))
+
+	ctx, cancel := context.WithTimeout(ctx, endpointsTestTimeout)
+	defer cancel()
+
+	pairs := make([]dms.ConnectionPair, len(instanceARNs))
+	for i, arn := range instanceARNs {
+		pairs[i] = dms.ConnectionPair{InstanceARN: arn, EndpointARN: ep.ARN}
+	}
+	checks := client.TestConnections(ctx, pairs, endpointsTestInterval)
+
+	failed := 0
+	for _, check := range checks {
+		name := connectionInstanceName(check)
+		switch {
+		case check.Error != nil:
+			failed++
+			fmt.Printf("✗ %s: %v\n", name, check.Error)
+		case !check.Passed():
+			failed++
+			fmt.Printf("✗ %s: %s", name, check.Connection.Status)
+			if check.Connection.LastFailureMessage != "" {
+				fmt.Printf(": %s", check.Connection.LastFailureMessage)
+			}
+			fmt.Println()
+		default:
+			fmt.Printf("✓ %s: %s\n", name, check.Connection.Status)
+		}
+	}
+
+	fmt.Printf("\n%d of %d connection test(s) succeeded\n", len(instanceARNs)-failed, len(instanceARNs))
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// endpointTestInstances resolves the --instance flags to ARNs, defaulting to
+// the instances hosting tasks that use the endpoint
+func endpointTestInstances(ctx context.Context, client *dms.Client, ep *dms.Endpoint) ([]string, error) {
+	var arns []string
+	if len(endpointsTestInstances) > 0 {
+		for _, id := range endpointsTestInstances {
+			inst, err := client.DescribeReplicationInstance(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			arns = append(arns, inst.ARN)
+		}
+		return arns, nil
+	}
+
+	tasks, err := client.ListTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, task := range endpointTasks(tasks, ep.ARN) {
+		if !seen[task.ReplicationInstanceARN] {
+			seen[task.ReplicationInstanceARN] = true
+			arns = append(arns, task.ReplicationInstanceARN)
+		}
+	}
+	if len(arns) == 0 {
+		return nil, fmt.Errorf("no task uses endpoint %s; choose instances with --instance", ep.Identifier)
+	}
+	return arns, nil
+}
+
+// endpointTasks returns the tasks using an endpoint as source or target
+func endpointTasks(tasks []dms.Task, endpointARN string) []dms.Task {
+	var matched []dms.Task
+	for _, task := range tasks {
+		if task.SourceEndpointARN == endpointARN || task.TargetEndpointARN == endpointARN {
+			matched = append(matched, task)
+		}
+	}
+	return matched
+}
+
+func printEndpointDetails(ep *dms.Endpoint, tasks []dms.Task, connections []dms.Connection) {
+	fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("Endpoint:"), tui.CLIPrimaryStyle.Render(ep.Identifier))
+	fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("ARN:"), tui.CLIMutedStyle.Render(ep.ARN))
+	fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("Status:"), getEndpointStatusStyle(ep.Status).Render(ep.Status))
+	fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("Type:"), tui.CLIValueStyle.Render(strings.ToLower(ep.Type)))
+	fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("Engine:"), tui.CLIValueStyle.Render(ep.Engine))
+	if server := formatEndpointServer(*ep); server != "" {
+		fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("Server:"), tui.CLIValueStyle.Render(server))
+	}
+	if ep.DatabaseName != "" {
+		fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("Database:"), tui.CLIValueStyle.Render(ep.DatabaseName))
+	}
+
+	fmt.Println("\n" + tui.CLIHighlightStyle.Render(fmt.Sprintf("Used By Tasks (%d):", len(tasks))))
+	if len(tasks) == 0 {
+		fmt.Println("  " + tui.CLIMutedStyle.Render("none"))
+	}
+	for _, task := range tasks {
+		role := "target"
+		if task.SourceEndpointARN == ep.ARN {
+			role = "source"
+		}
+		fmt.Printf("  %s %s %s\n", tui.CLIPrimaryStyle.Render(task.Name), getListStatusStyle(task.Status).Render(task.Status), tui.CLIMutedStyle.Render("("+role+")"))
+	}
+
+	fmt.Println("\n" + tui.CLIHighlightStyle.Render("Connection Tests:"))
+	if len(connections) == 0 {
+		fmt.Println("  " + tui.CLIMutedStyle.Render("none"))
+	}
+	for _, conn := range connections {
+		fmt.Printf("  %s %s\n", tui.CLIPrimaryStyle.Render(conn.ReplicationInstanceIdentifier), getConnectionStatusStyle(conn.Status).Render(conn.Status))
+		if conn.LastFailureMessage != "" {
+			fmt.Printf("    %s\n", tui.CLIErrorStyle.Render(conn.LastFailureMessage))
+		}
+	}
+}
+
+// formatEndpointServer returns the endpoint's server and port, if set
+func formatEndpointServer(ep dms.Endpoint) string {
+	if ep.ServerName == "" {
+		return ""
+	}
+	if ep.Port == 0 {
+		return ep.ServerName
+	}
+	return fmt.Sprintf("%s:%d", ep.ServerName, ep.Port)
+}
+
+// connectionInstanceName returns the identifier of the instance a check ran
+// from, falling back to the end of its ARN when the test did not start
+func connectionInstanceName(check dms.ConnectionCheck) string {
+	if check.Connection != nil && check.Connection.ReplicationInstanceIdentifier != "" {
+		return check.Connection.ReplicationInstanceIdentifier
+	}
+	return getInstanceNameFromARN(check.InstanceARN)
+}
+
+// getInstanceNameFromARN extracts the instance name from an ARN
+func getInstanceNameFromARN(arn string) string {
+	parts := strings.Split(arn, ":")
+	if len(parts) > 0 {
+		return parts[len(parts)-1]
+	}
+	return arn
+}
+
+// getEndpointStatusStyle returns the appropriate style for an endpoint status
+func getEndpointStatusStyle(status string) lipgloss.Style {
+	switch strings.ToLower(status) {
+	case "active":
+		return tui.CLISuccessStyle
+	case "deleting":
+		return tui.CLIErrorStyle
+	default:
+		return tui.CLIWarningStyle
+	}
+}
+
+// getConnectionStatusStyle returns the appropriate style for a connection
+// test status
+func getConnectionStatusStyle(status string) lipgloss.Style {
+	switch strings.ToLower(status) {
+	case "successful":
+		return tui.CLISuccessStyle
+	case "failed":
+		return tui.CLIErrorStyle
+	default:
+		return tui.CLIWarningStyle
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/eljosho/dms-manager/pkg/dms"
+	"github.com/spf13/cobra"
 )
 
 // expandWildcards checks if any identifier contains wildcards and expands to matching tasks
@@ -150,3 +152,51 @@ func getTaskNameFromARN(arn string) string {
 	}
 	return arn
 }
+
+// addRefreshFlags registers the flags controlling the refresh scheduler
+func addRefreshFlags(cmd *cobra.Command, defaultInterval time.Duration) {
+	cmd.Flags().Duration("interval", defaultInterval, "Time between refreshes (overrides refresh.interval in the config file)")
+	cmd.Flags().Bool("adaptive", true, "Poll faster while tasks start or stop and slower while nothing changes")
+}
+
+// refreshPolicy builds the refresh policy of a watch-style command from the
+// defaults, the config file and the command flags, in increasing precedence
+func refreshPolicy(cmd *cobra.Command) (dms.RefreshPolicy, error) {
+	policy := dms.DefaultRefreshPolicy
+	policy.Interval, _ = cmd.Flags().GetDuration("interval")
+
+	cfg := appConfig.Refresh
+	for _, d := range []struct {
+		value string
+		name  string
+		dst   *time.Duration
+	}{
+		{cfg.Interval, "refresh.interval", &policy.Interval},
+		{cfg.Fast, "refresh.fast", &policy.Fast},
+		{cfg.Idle, "refresh.idle", &policy.Idle},
+	} {
+		if d.value == "" {
+			continue
+		}
+		v, err := time.ParseDuration(d.value)
+		if err != nil {
+			return policy, fmt.Errorf("invalid %s in config: %w", d.name, err)
+		}
+		*d.dst = v
+	}
+	if cfg.Adaptive != nil {
+		policy.Adaptive = *cfg.Adaptive
+	}
+
+	if cmd.Flags().Changed("interval") {
+		policy.Interval, _ = cmd.Flags().GetDuration("interval")
+	}
+	if cmd.Flags().Changed("adaptive") {
+		policy.Adaptive, _ = cmd.Flags().GetBool("adaptive")
+	}
+
+	// Keep the idle delay above a long configured interval
+	policy.Idle = max(policy.Idle, policy.Interval)
+
+	return policy, policy.Validate()
+}
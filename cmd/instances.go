@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eljosho/dms-manager/internal/tui"
+	"github.com/eljosho/dms-manager/pkg/dms"
+	"github.com/spf13/cobra"
+)
+
+var instancesForceFailover bool
+
+var instancesCmd = &cobra.Command{
+	Use:   "instances",
+	Short: "Manage DMS replication instances",
+	Long: `List, describe and reboot the replication instances that host DMS tasks.
+
+Instances can be given by identifier or ARN.
+
+Examples:
+  dms-manager instances list
+  dms-manager instances describe prod-instance
+  dms-manager instances reboot prod-instance --force-failover`,
+}
+
+var instancesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all replication instances",
+	Long:  `List all replication instances with their class, storage, status and the number of tasks hosted on each.`,
+	Args:  cobra.NoArgs,
+	Run:   runInstancesList,
+}
+
+var instancesDescribeCmd = &cobra.Command{
+	Use:   "describe [instance...]",
+	Short: "Get detailed information about replication instances",
+	Long: `Get detailed information about one or more replication instances,
+including pending modifications and the tasks hosted on each.`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runInstancesDescribe,
+}
+
+var instancesRebootCmd = &cobra.Command{
+	Use:   "reboot [instance]",
+	Short: "Reboot a replication instance",
+	Long: `Reboot a replication instance. Tasks hosted on it are interrupted while
+the instance restarts.
+
+With --force-failover a Multi-AZ instance fails over to its standby in the
+secondary availability zone instead of rebooting in place.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runInstancesReboot,
+}
+
+func init() {
+	instancesRebootCmd.Flags().BoolVar(&instancesForceFailover, "force-failover", false, "Fail over to the standby of a Multi-AZ instance")
+	instancesCmd.AddCommand(instancesListCmd, instancesDescribeCmd, instancesRebootCmd)
+	rootCmd.AddCommand(instancesCmd)
+}
+
+func runInstancesList(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	client, err := dms.NewClient(ctx, GetProfile(), GetRegion())
+	if err != nil {
+		exitWithError(fmt.Errorf("failed to create DMS client: %w", err))
+	}
+
+	fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("Region:"), tui.CLIPrimaryStyle.Render(client.GetRegion()))
+	if client.GetProfile() != "" {
+		fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("Profile:"), tui.CLISecondaryStyle.Render(client.GetProfile()))
+	}
+	fmt.Println()
+
+	instances, err := client.ListReplicationInstances(ctx)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if len(instances) == 0 {
+		fmt.Println(tui.CLIWarningStyle.Render("No DMS replication instances found."))
+		return
+	}
+
+	tasks, err := client.ListTasks(ctx)
+	if err != nil {
+		exitWithError(err)
+	}
+	hosted := dms.TasksByInstance(tasks)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	headers := []string{"IDENTIFIER", "CLASS", "ENGINE", "STORAGE", "AZ", "STATUS", "TASKS", "PENDING"}
+	var coloredHeaders []string
+	var coloredSeps []string
+	for _, h := range headers {
+		coloredHeaders = append(coloredHeaders, tui.CLIHeaderStyle.Render(h))
+		coloredSeps = append(coloredSeps, tui.CLIMutedStyle.Render(strings.Repeat("─", len(h))))
+	}
+	fmt.Fprintln(w, strings.Join(coloredHeaders, "\t"))
+	fmt.Fprintln(w, strings.Join(coloredSeps, "\t"))
+
+	for _, inst := range instances {
+		pending := "-"
+		if len(inst.PendingModifications) > 0 {
+			pending = strings.Join(inst.PendingModifications, ", ")
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			tui.CLIPrimaryStyle.Render(inst.Identifier),
+			tui.CLIValueStyle.Render(inst.Class),
+			tui.CLIValueStyle.Render(inst.EngineVersion),
+			tui.CLINumberStyle.Render(fmt.Sprintf("%d GB", inst.AllocatedStorage)),
+			tui.CLIValueStyle.Render(formatInstanceAZ(inst)),
+			getInstanceStatusStyle(inst.Status).Render(inst.Status),
+			tui.CLINumberStyle.Render(fmt.Sprintf("%d", len(hosted[inst.ARN]))),
+			tui.CLIWarningStyle.Render(pending),
+		)
+	}
+	w.Flush()
+
+	fmt.Printf("\n%s %s\n", tui.CLILabelStyle.Render("Total instances:"), tui.CLINumberStyle.Render(fmt.Sprintf("%d", len(instances))))
+}
+
+func runInstancesDescribe(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	client, err := dms.NewClient(ctx, GetProfile(), GetRegion())
+	if err != nil {
+		exitWithError(fmt.Errorf("failed to create DMS client: %w", err))
+	}
+
+	tasks, err := client.ListTasks(ctx)
+	if err != nil {
+		exitWithError(err)
+	}
+	hosted := dms.TasksByInstance(tasks)
+
+	for i, arg := range args {
+		if i > 0 {
+			fmt.Println("\n" + tui.CLIMutedStyle.Render(strings.Repeat("─", 80)))
+		}
+
+		inst, err := client.DescribeReplicationInstance(ctx, arg)
+		if err != nil {
+			fmt.Printf("%s %s: %v\n", tui.CLIErrorStyle.Render("Error describing instance"), arg, err)
+			continue
+		}
+
+		printInstanceDetails(inst, hosted[inst.ARN])
+	}
+}
+
+func runInstancesReboot(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	client, err := dms.NewClient(ctx, GetProfile(), GetRegion())
+	if err != nil {
+		exitWithError(fmt.Errorf("failed to create DMS client: %w", err))
+	}
+
+	inst, err := client.DescribeReplicationInstance(ctx, args[0])
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if instancesForceFailover && !inst.MultiAZ {
+		exitWithError(fmt.Errorf("instance %s is not Multi-AZ; --force-failover requires a standby", inst.Identifier))
+	}
+
+	if instancesForceFailover {
+		fmt.Printf("Rebooting %s with failover to %s...\n", inst.Identifier, inst.SecondaryAvailabilityZone)
+	} else {
+		fmt.Printf("Rebooting %s...\n", inst.Identifier)
+	}
+
+	rebooted, err := client.RebootReplicationInstance(ctx, inst.ARN, instancesForceFailover)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	fmt.Printf("✓ %s: status %s\n", rebooted.Identifier, rebooted.Status)
+}
+
+func printInstanceDetails(inst *dms.ReplicationInstance, tasks []dms.Task) {
+	fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("Instance:"), tui.CLIPrimaryStyle.Render(inst.Identifier))
+	fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("ARN:"), tui.CLIMutedStyle.Render(inst.ARN))
+	fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("Status:"), getInstanceStatusStyle(inst.Status).Render(inst.Status))
+	fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("Class:"), tui.CLIValueStyle.Render(inst.Class))
+	fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("Engine Version:"), tui.CLIValueStyle.Render(inst.EngineVersion))
+	fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("Storage:"), tui.CLINumberStyle.Render(fmt.Sprintf("%d GB", inst.AllocatedStorage)))
+
+	fmt.Println("\n" + tui.CLIHighlightStyle.Render("Availability:"))
+	fmt.Printf("  %s %s\n", tui.CLILabelStyle.Render("Multi-AZ:"), tui.CLIValueStyle.Render(fmt.Sprintf("%t", inst.MultiAZ)))
+	fmt.Printf("  %s %s\n", tui.CLILabelStyle.Render("Availability Zone:"), tui.CLIValueStyle.Render(inst.AvailabilityZone))
+	if inst.SecondaryAvailabilityZone != "" {
+		fmt.Printf("  %s %s\n", tui.CLILabelStyle.Render("Standby Zone:"), tui.CLIValueStyle.Render(inst.SecondaryAvailabilityZone))
+	}
+	fmt.Printf("  %s %s\n", tui.CLILabelStyle.Render("Publicly Accessible:"), tui.CLIValueStyle.Render(fmt.Sprintf("%t", inst.PubliclyAccessible)))
+	if inst.MaintenanceWindow != "" {
+		fmt.Printf("  %s %s\n", tui.CLILabelStyle.Render("Maintenance Window:"), tui.CLIValueStyle.Render(inst.MaintenanceWindow))
+	}
+
+	if inst.CreatedAt != nil {
+		fmt.Printf("\n%s %s\n", tui.CLILabelStyle.Render("Created At:"), tui.CLIValueStyle.Render(inst.CreatedAt.Format("2006-01-02 15:04:05")))
+	}
+
+	if len(inst.PendingModifications) > 0 {
+		fmt.Println("\n" + tui.CLIWarningStyle.Render("Pending Modifications:"))
+		for _, mod := range inst.PendingModifications {
+			fmt.Printf("  %s\n", tui.CLIValueStyle.Render(mod))
+		}
+	}
+
+	fmt.Println("\n" + tui.CLIHighlightStyle.Render(fmt.Sprintf("Hosted Tasks (%d):", len(tasks))))
+	if len(tasks) == 0 {
+		fmt.Println("  " + tui.CLIMutedStyle.Render("none"))
+	}
+	for _, task := range tasks {
+		fmt.Printf("  %s %s\n", tui.CLIPrimaryStyle.Render(task.Name), getListStatusStyle(task.Status).Render(task.Status))
+	}
+}
+
+// formatInstanceAZ returns the instance's zone, followed by the standby zone
+// of a Multi-AZ instance
+func formatInstanceAZ(inst dms.ReplicationInstance) string {
+	if inst.MultiAZ && inst.SecondaryAvailabilityZone != "" {
+		return inst.AvailabilityZone + " / " + inst.SecondaryAvailabilityZone
+	}
+	return inst.AvailabilityZone
+}
+
+// getInstanceStatusStyle returns the appropriate style for an instance status
+func getInstanceStatusStyle(status string) lipgloss.Style {
+	switch strings.ToLower(status) {
+	case "available":
+		return tui.CLISuccessStyle
+	case "failed", "storage-full", "incompatible-network", "inaccessible-encryption-credentials":
+		return tui.CLIErrorStyle
+	default:
+		return tui.CLIWarningStyle
+	}
+}
@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eljosho/dms-manager/internal/lint"
+	"github.com/eljosho/dms-manager/internal/tui"
+	"github.com/eljosho/dms-manager/pkg/dms"
+	"github.com/spf13/cobra"
+)
+
+var (
+	lintOutput         string
+	lintFailOn         string
+	lintShowSuppressed bool
+	lintListRules      bool
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [task-arn-or-name...]",
+	Short: "Check task settings and table mappings against best practices",
+	Long: `Check the settings and table mappings of tasks, all tasks if none are given,
+against best-practice rules such as logging being on and target tables not
+being dropped on CDC tasks. Each rule has a severity: info, warning or error.
+
+Rules can be turned off, given another severity or suppressed for some tasks
+in the "lint" section of the config file. A task can also suppress rules with
+the tag dms-manager:lint-ignore, whose value lists rule IDs separated by
+commas, or "*" for all rules.
+
+The command exits with status 1 if there are unsuppressed findings of the
+--fail-on severity or above, so it can gate CI pipelines.
+
+Wildcards are supported for task names (e.g. "prod-*", "*-database").
+
+Examples:
+  dms-manager lint
+  dms-manager lint "prod-*" --fail-on warning
+  dms-manager lint "prod-*" -o json
+  dms-manager lint --rules`,
+	Run: runLint,
+}
+
+func init() {
+	lintCmd.Flags().StringVarP(&lintOutput, "output", "o", "table", "Output format: table or json")
+	lintCmd.Flags().StringVar(&lintFailOn, "fail-on", "error", "Exit with status 1 on findings of this severity or above: info, warning, error or none")
+	lintCmd.Flags().BoolVar(&lintShowSuppressed, "show-suppressed", false, "List suppressed findings too")
+	lintCmd.Flags().BoolVar(&lintListRules, "rules", false, "List the rules and exit")
+	rootCmd.AddCommand(lintCmd)
+}
+
+// lintReport is the structured output of the lint command
+type lintReport struct {
+	Findings []lint.Finding `json:"findings"`
+	Summary  lintSummary    `json:"summary"`
+	Failed   bool           `json:"failed"`
+}
+
+// lintSummary counts unsuppressed findings by severity, and suppressed ones
+type lintSummary struct {
+	Tasks      int `json:"tasks"`
+	Errors     int `json:"errors"`
+	Warnings   int `json:"warnings"`
+	Infos      int `json:"infos"`
+	Suppressed int `json:"suppressed"`
+}
+
+func runLint(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	if lintListRules {
+		printLintRules()
+		return
+	}
+	if lintOutput != "table" && lintOutput != "json" {
+		exitWithError(fmt.Errorf("invalid output format %q (use table or json)", lintOutput))
+	}
+	failOn := lint.Severity(-1)
+	if lintFailOn != "none" {
+		var err error
+		if failOn, err = lint.ParseSeverity(lintFailOn); err != nil {
+			exitWithError(fmt.Errorf("--fail-on: %w", err))
+		}
+	}
+
+	client, err := dms.NewClient(ctx, GetProfile(), GetRegion())
+	if err != nil {
+		exitWithError(fmt.Errorf("failed to create DMS client: %w", err))
+	}
+
+	tasks, err := client.ListTasks(ctx)
+	if err != nil {
+		exitWithError(err)
+	}
+	if len(args) > 0 {
+		taskARNs, err := resolveTaskARNs(ctx, client, args)
+		if err != nil {
+			exitWithError(err)
+		}
+		byARN, err := tasksByARN(ctx, client, taskARNs)
+		if err != nil {
+			exitWithError(err)
+		}
+		tasks = tasks[:0]
+		for _, arn := range taskARNs {
+			tasks = append(tasks, byARN[arn])
+		}
+	}
+	if len(tasks) == 0 {
+		exitWithError(fmt.Errorf("no valid tasks found"))
+	}
+
+	// Without tags, only the config file can suppress rules
+	arns := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		arns = append(arns, task.ARN)
+	}
+	tags, err := client.ListTaskTags(ctx, arns)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s %v; %s tags are ignored\n", tui.CLIWarningStyle.Render("Warning:"), err, lint.IgnoreTag)
+	}
+
+	targets := make([]lint.Target, 0, len(tasks))
+	for _, task := range tasks {
+		target, err := lint.NewTarget(task, tags[task.ARN])
+		if err != nil {
+			exitWithError(err)
+		}
+		targets = append(targets, target)
+	}
+
+	findings, err := lint.Run(targets, appConfig.Lint)
+	if err != nil {
+		exitWithError(fmt.Errorf("invalid config: %w", err))
+	}
+
+	report := lintReport{Findings: []lint.Finding{}, Summary: lintSummary{Tasks: len(tasks)}}
+	for _, f := range findings {
+		if f.Suppressed != "" {
+			report.Summary.Suppressed++
+			if !lintShowSuppressed {
+				continue
+			}
+		} else {
+			switch f.Severity {
+			case lint.Error:
+				report.Summary.Errors++
+			case lint.Warning:
+				report.Summary.Warnings++
+			default:
+				report.Summary.Infos++
+			}
+			if failOn >= 0 && f.Severity >= failOn {
+				report.Failed = true
+			}
+		}
+		report.Findings = append(report.Findings, f)
+	}
+
+	if lintOutput == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			exitWithError(err)
+		}
+	} else {
+		printLintReport(report)
+	}
+
+	if report.Failed {
+		os.Exit(1)
+	}
+}
+
+func printLintReport(report lintReport) {
+	if len(report.Findings) > 0 {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		headers := []string{"TASK", "SEVERITY", "RULE", "MESSAGE"}
+		var coloredHeaders []string
+		var coloredSeps []string
+		for _, h := range headers {
+			coloredHeaders = append(coloredHeaders, tui.CLIHeaderStyle.Render(h))
+			coloredSeps = append(coloredSeps, tui.CLIMutedStyle.Render(strings.Repeat("─", len(h))))
+		}
+		fmt.Fprintln(w, strings.Join(coloredHeaders, "\t"))
+		fmt.Fprintln(w, strings.Join(coloredSeps, "\t"))
+
+		for _, f := range report.Findings {
+			severity := getLintSeverityStyle(f.Severity).Render(f.Severity.String())
+			message := tui.CLIValueStyle.Render(f.Message)
+			if f.Suppressed != "" {
+				severity = tui.CLIMutedStyle.Render(f.Severity.String())
+				message = tui.CLIMutedStyle.Render(f.Message + " (suppressed by " + f.Suppressed + ")")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+				tui.CLIPrimaryStyle.Render(f.Task),
+				severity,
+				tui.CLIMutedStyle.Render(f.Rule),
+				message,
+			)
+		}
+		w.Flush()
+		fmt.Println()
+	}
+
+	s := report.Summary
+	summary := fmt.Sprintf("%d task(s) checked: %d error(s), %d warning(s), %d info", s.Tasks, s.Errors, s.Warnings, s.Infos)
+	if s.Suppressed > 0 {
+		summary += fmt.Sprintf(", %d suppressed", s.Suppressed)
+	}
+	if s.Errors+s.Warnings+s.Infos == 0 {
+		fmt.Println("✓ " + summary)
+	} else {
+		fmt.Println(summary)
+	}
+}
+
+func printLintRules() {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%s\t%s\t%s\n",
+		tui.CLIHeaderStyle.Render("RULE"),
+		tui.CLIHeaderStyle.Render("SEVERITY"),
+		tui.CLIHeaderStyle.Render("DESCRIPTION"))
+	for _, r := range lint.Rules() {
+		fmt.Fprintf(w, "%s\t%s\t%s\n",
+			tui.CLIPrimaryStyle.Render(r.ID),
+			getLintSeverityStyle(r.Severity).Render(r.Severity.String()),
+			tui.CLIValueStyle.Render(r.Description))
+	}
+	w.Flush()
+}
+
+// getLintSeverityStyle returns the style of a finding severity
+func getLintSeverityStyle(s lint.Severity) lipgloss.Style {
+	switch s {
+	case lint.Error:
+		return tui.CLIErrorStyle
+	case lint.Warning:
+		return tui.CLIWarningStyle
+	default:
+		return tui.CLIMutedStyle
+	}
+}
@@ -62,6 +62,9 @@ func runList(cmd *cobra.Command, args []string) {
 			fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("Status:"), getListStatusStyle(task.Status).Render(task.Status))
 			fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("Type:"), tui.CLIValueStyle.Render(task.MigrationType))
 			fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("ARN:"), tui.CLIMutedStyle.Render(task.ARN))
+			if task.StopReason != "" {
+				fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("Stop Reason:"), tui.CLIWarningStyle.Render(task.StopReason))
+			}
 
 			if task.ReplicationTaskStats != nil {
 				stats := task.ReplicationTaskStats
@@ -75,10 +78,6 @@ func runList(cmd *cobra.Command, args []string) {
 				if stats.ElapsedTimeMillis > 0 {
 					fmt.Printf("  %s %s\n", tui.CLILabelStyle.Render("Elapsed Time:"), tui.CLIValueStyle.Render(dms.FormatElapsedTime(stats.ElapsedTimeMillis)))
 				}
-
-				if stats.StopReason != "" {
-					fmt.Printf("  %s %s\n", tui.CLILabelStyle.Render("Stop Reason:"), tui.CLIWarningStyle.Render(stats.StopReason))
-				}
 			}
 			fmt.Println()
 		}
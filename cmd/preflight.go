@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/eljosho/dms-manager/internal/tui"
+	"github.com/eljosho/dms-manager/pkg/dms"
+	"github.com/spf13/cobra"
+)
+
+// preflightPollInterval is the time between polls for connection test results
+const preflightPollInterval = 5 * time.Second
+
+// addPreflightFlags registers the flags of the connectivity check run before
+// starting tasks
+func addPreflightFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("preflight", false, "Test the connections of each task's instance to its endpoints first, and skip tasks that fail")
+	cmd.Flags().Duration("preflight-timeout", 5*time.Minute, "Give up on connection tests that have not finished after this long")
+}
+
+// preflightTasks runs the connectivity check if --preflight is set and
+// returns the tasks that passed it. Each (instance, endpoint) pair is tested
+// once, however many tasks share it.
+func preflightTasks(ctx context.Context, cmd *cobra.Command, client *dms.Client, taskARNs []string) []string {
+	if enabled, _ := cmd.Flags().GetBool("preflight"); !enabled {
+		return taskARNs
+	}
+	timeout, _ := cmd.Flags().GetDuration("preflight-timeout")
+
+	all, err := client.ListTasks(ctx)
+	if err != nil {
+		exitWithError(err)
+	}
+	byARN := make(map[string]dms.Task, len(all))
+	for _, task := range all {
+		byARN[task.ARN] = task
+	}
+	var tasks []dms.Task
+	var missing []string
+	for _, arn := range taskARNs {
+		if task, ok := byARN[arn]; ok {
+			tasks = append(tasks, task)
+		} else {
+			missing = append(missing, arn)
+		}
+	}
+
+	pairs := dms.ConnectionPairs(tasks)
+	fmt.Printf("Preflight: testing %d connection(s) used by %d task(s)...\n\n", len(pairs), len(tasks))
+
+	testCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	checks := client.TestConnections(testCtx, pairs, preflightPollInterval)
+
+	// Endpoint names are a nicety; fall back to ARNs if they can't be listed
+	list, _ := client.ListEndpoints(ctx)
+	endpoints := dms.EndpointsByARN(list)
+	printConnectivityMatrix(checks, endpoints)
+
+	for _, arn := range missing {
+		fmt.Printf("✗ %s: skipped, task not found\n", arn)
+	}
+	var passed []string
+	for _, task := range tasks {
+		failed := dms.FailedChecks(task, checks)
+		if len(failed) == 0 {
+			passed = append(passed, task.ARN)
+			continue
+		}
+		var unreachable []string
+		for _, check := range failed {
+			unreachable = append(unreachable, endpointName(check.EndpointARN, endpoints))
+		}
+		fmt.Printf("✗ %s: skipped, %s unreachable from %s\n", task.Name, strings.Join(unreachable, " and "), connectionInstanceName(failed[0]))
+	}
+
+	fmt.Printf("\nPreflight passed for %d out of %d tasks\n\n", len(passed), len(taskARNs))
+	if len(passed) == 0 {
+		exitWithError(fmt.Errorf("no task passed the preflight check"))
+	}
+	return passed
+}
+
+// printConnectivityMatrix prints one row per replication instance and one
+// column per endpoint, followed by the reasons of failed tests
+func printConnectivityMatrix(checks []dms.ConnectionCheck, endpoints map[string]dms.Endpoint) {
+	var instances, endpointARNs []string
+	instanceNames := make(map[string]string)
+	results := make(map[dms.ConnectionPair]dms.ConnectionCheck)
+	for _, check := range checks {
+		if _, ok := instanceNames[check.InstanceARN]; !ok {
+			instances = append(instances, check.InstanceARN)
+			instanceNames[check.InstanceARN] = connectionInstanceName(check)
+		}
+		// Checks that ran know the identifier; ones that errored only the ARN
+		if check.Connection != nil && check.Connection.ReplicationInstanceIdentifier != "" {
+			instanceNames[check.InstanceARN] = check.Connection.ReplicationInstanceIdentifier
+		}
+		if !slices.Contains(endpointARNs, check.EndpointARN) {
+			endpointARNs = append(endpointARNs, check.EndpointARN)
+		}
+		results[check.ConnectionPair] = check
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	header := []string{tui.CLIHeaderStyle.Render("INSTANCE")}
+	for _, arn := range endpointARNs {
+		header = append(header, tui.CLIHeaderStyle.Render(endpointName(arn, endpoints)))
+	}
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+
+	for _, inst := range instances {
+		row := []string{tui.CLIPrimaryStyle.Render(instanceNames[inst])}
+		for _, ep := range endpointARNs {
+			check, tested := results[dms.ConnectionPair{InstanceARN: inst, EndpointARN: ep}]
+			switch {
+			case !tested:
+				row = append(row, tui.CLIMutedStyle.Render("-"))
+			case check.Passed():
+				row = append(row, tui.CLISuccessStyle.Render("✓ ok"))
+			default:
+				row = append(row, tui.CLIErrorStyle.Render("✗ "+checkStatus(check)))
+			}
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+
+	var failures []string
+	for _, check := range checks {
+		if check.Passed() {
+			continue
+		}
+		reason := checkStatus(check)
+		if check.Error != nil {
+			reason = check.Error.Error()
+		} else if check.Connection.LastFailureMessage != "" {
+			reason = check.Connection.LastFailureMessage
+		}
+		failures = append(failures, fmt.Sprintf("  %s → %s: %s", connectionInstanceName(check), endpointName(check.EndpointARN, endpoints), reason))
+	}
+	if len(failures) > 0 {
+		fmt.Println("\n" + tui.CLIErrorStyle.Render("Failed connections:"))
+		fmt.Println(strings.Join(failures, "\n"))
+	}
+	fmt.Println()
+}
+
+// checkStatus returns the short status of a connection check for the matrix
+func checkStatus(check dms.ConnectionCheck) string {
+	if check.Error != nil || check.Connection == nil {
+		return "error"
+	}
+	return check.Connection.Status
+}
+
+// endpointName returns an endpoint's identifier, or the end of its ARN if
+// the endpoint is unknown
+func endpointName(arn string, endpoints map[string]dms.Endpoint) string {
+	if ep, ok := endpoints[arn]; ok {
+		return ep.Identifier
+	}
+	parts := strings.Split(arn, ":")
+	return parts[len(parts)-1]
+}
@@ -22,12 +22,14 @@ Note: When using wildcards, you MUST quote the argument to prevent shell expansi
 
 Examples:
   dms-manager reload task1 task2
-  dms-manager reload "*-database"`,
+  dms-manager reload "*-database"
+  dms-manager reload "*-database" --preflight`,
 	Args: cobra.MinimumNArgs(1),
 	Run:  runReload,
 }
 
 func init() {
+	addPreflightFlags(reloadCmd)
 	rootCmd.AddCommand(reloadCmd)
 }
 
@@ -49,6 +51,9 @@ func runReload(cmd *cobra.Command, args []string) {
 		exitWithError(fmt.Errorf("no valid tasks found"))
 	}
 
+	requested := len(taskARNs)
+	taskARNs = preflightTasks(ctx, cmd, client, taskARNs)
+
 	fmt.Printf("Reloading %d task(s) in parallel...\n\n", len(taskARNs))
 
 	// Use reload-target start type
@@ -65,5 +70,5 @@ func runReload(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	fmt.Printf("\nSuccessfully reloaded %d out of %d tasks\n", successCount, len(taskARNs))
+	fmt.Printf("\nSuccessfully reloaded %d out of %d tasks\n", successCount, requested)
 }
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/eljosho/dms-manager/internal/tui"
+	"github.com/eljosho/dms-manager/pkg/dms"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reloadTablesPatterns     []string
+	reloadTablesValidateOnly bool
+)
+
+var reloadTablesCmd = &cobra.Command{
+	Use:   "reload-tables [task-arn-or-name]",
+	Short: "Reload individual tables of a DMS replication task",
+	Long: `Reload individual tables of a running DMS replication task from the source,
+leaving the other tables untouched.
+
+Tables are given as schema.table and matched against the task's table
+statistics. Wildcards are supported (e.g. "sales.*", "*.orders_202?").
+Note: When using wildcards, you MUST quote the argument to prevent shell expansion.
+
+With --validate-only the tables are revalidated without reloading their data.
+
+Examples:
+  dms-manager reload-tables my-task --table sales.orders
+  dms-manager reload-tables my-task --table sales.orders --table sales.customers
+  dms-manager reload-tables my-task --table "sales.*" --validate-only`,
+	Args: cobra.ExactArgs(1),
+	Run:  runReloadTables,
+}
+
+func init() {
+	reloadTablesCmd.Flags().StringSliceVarP(&reloadTablesPatterns, "table", "t", nil, "Table to reload as schema.table (repeatable, wildcards allowed)")
+	reloadTablesCmd.Flags().BoolVar(&reloadTablesValidateOnly, "validate-only", false, "Revalidate the tables without reloading their data")
+	reloadTablesCmd.MarkFlagRequired("table")
+	rootCmd.AddCommand(reloadTablesCmd)
+}
+
+func runReloadTables(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	client, err := dms.NewClient(ctx, GetProfile(), GetRegion())
+	if err != nil {
+		exitWithError(fmt.Errorf("failed to create DMS client: %w", err))
+	}
+
+	taskARNs, err := resolveTaskARNs(ctx, client, args)
+	if err != nil {
+		exitWithError(err)
+	}
+	if len(taskARNs) != 1 {
+		exitWithError(fmt.Errorf("reload-tables works on exactly one task, got %d", len(taskARNs)))
+	}
+	taskARN := taskARNs[0]
+
+	stats, err := client.GetTableStatistics(ctx, taskARN)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	tables, err := matchTables(stats, reloadTablesPatterns)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	mode := dms.ReloadData
+	verb := "Reloading"
+	if reloadTablesValidateOnly {
+		mode = dms.ReloadValidateOnly
+		verb = "Revalidating"
+	}
+
+	fmt.Printf("%s %d table(s) of %s:\n", verb, len(tables), getTaskNameFromARN(taskARN))
+	for _, t := range tables {
+		fmt.Printf("  %s\n", tui.CLIValueStyle.Render(t.String()))
+	}
+	fmt.Println()
+
+	if err := client.ReloadTables(ctx, taskARN, tables, mode); err != nil {
+		exitWithError(err)
+	}
+
+	fmt.Printf("✓ %s: %s requested for %d table(s)\n", getTaskNameFromARN(taskARN), mode, len(tables))
+}
+
+// matchTables resolves schema.table patterns against a task's tables. Every
+// pattern must match at least one table.
+func matchTables(stats []dms.TableStatistic, patterns []string) ([]dms.TableRef, error) {
+	seen := make(map[dms.TableRef]bool)
+	var tables []dms.TableRef
+
+	for _, pattern := range patterns {
+		if !strings.Contains(pattern, ".") {
+			return nil, fmt.Errorf("invalid table %q: use schema.table", pattern)
+		}
+
+		matched := false
+		for _, s := range stats {
+			ref := dms.TableRefOf(s)
+			if !matchPattern(pattern, ref.String()) {
+				continue
+			}
+			matched = true
+			if !seen[ref] {
+				seen[ref] = true
+				tables = append(tables, ref)
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("no table of the task matches %q", pattern)
+		}
+	}
+
+	return tables, nil
+}
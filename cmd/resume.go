@@ -23,14 +23,20 @@ Note: When using wildcards, you MUST quote the argument to prevent shell expansi
 Examples:
   dms-manager resume task1 task2
   dms-manager resume "*-database"
+  dms-manager resume "*-database" --preflight
+  dms-manager resume "*-database" --cdc-stop-position server_time:2024-05-01T12:00:00
+  dms-manager resume "*-database" --from-checkpoint latest
 
 This uses the resume-processing start type, which resumes replication 
-from where it was stopped.`,
+from where it was stopped, or from --cdc-start-time or --cdc-start-position
+for cdc tasks.`,
 	Args: cobra.MinimumNArgs(1),
 	Run:  runResume,
 }
 
 func init() {
+	addPreflightFlags(resumeCmd)
+	addCDCFlags(resumeCmd)
 	rootCmd.AddCommand(resumeCmd)
 }
 
@@ -52,9 +58,15 @@ func runResume(cmd *cobra.Command, args []string) {
 		exitWithError(fmt.Errorf("no valid tasks found"))
 	}
 
+	cdc := cdcOptions(ctx, cmd, client, taskARNs)
+
+	requested := len(taskARNs)
+	taskARNs = preflightTasks(ctx, cmd, client, taskARNs)
+	confirmCDC(cmd, "Resuming", taskARNs, cdc)
+
 	fmt.Printf("Resuming %d task(s) in parallel...\n\n", len(taskARNs))
 
-	results := client.StartTasks(ctx, taskARNs, types.StartReplicationTaskTypeValueResumeProcessing)
+	results := client.StartTasksAt(ctx, taskARNs, types.StartReplicationTaskTypeValueResumeProcessing, cdc.forTask)
 
 	// Print results
 	successCount := 0
@@ -67,5 +79,5 @@ func runResume(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	fmt.Printf("\nSuccessfully resumed %d out of %d tasks\n", successCount, len(taskARNs))
+	fmt.Printf("\nSuccessfully resumed %d out of %d tasks\n", successCount, requested)
 }
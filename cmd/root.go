@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/eljosho/dms-manager/internal/config"
+	"github.com/eljosho/dms-manager/internal/tui"
+	"github.com/eljosho/dms-manager/pkg/dms"
 	"github.com/spf13/cobra"
 )
 
@@ -11,6 +14,10 @@ var (
 	// Global flags
 	profile string
 	region  string
+	noColor bool
+
+	// appConfig is the config file loaded before any command runs
+	appConfig = &config.Config{}
 
 	// Root command
 	rootCmd = &cobra.Command{
@@ -20,6 +27,7 @@ var (
 		
 Supports both CLI commands and an interactive TUI for listing, describing, and controlling
 DMS tasks across different AWS profiles and regions.`,
+		PersistentPreRun: loadConfig,
 	}
 )
 
@@ -32,6 +40,20 @@ func init() {
 	// Global flags available to all commands
 	rootCmd.PersistentFlags().StringVarP(&profile, "profile", "p", "", "AWS profile to use (default: default profile)")
 	rootCmd.PersistentFlags().StringVarP(&region, "r", "", "", "AWS region (default: from profile or AWS_REGION)")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output (also honors NO_COLOR)")
+}
+
+// loadConfig applies the theme and key bindings from the config file before
+// any command runs
+func loadConfig(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(err)
+	}
+	if err := tui.Configure(cfg, noColor); err != nil {
+		exitWithError(fmt.Errorf("invalid config: %w", err))
+	}
+	appConfig = cfg
 }
 
 // GetProfile returns the global profile flag value
@@ -47,5 +69,8 @@ func GetRegion() string {
 // exitWithError prints an error and exits
 func exitWithError(err error) {
 	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	if dms.IsExpiredCredentials(err) {
+		fmt.Fprintln(os.Stderr, dms.LoginHint(GetProfile()))
+	}
 	os.Exit(1)
 }
@@ -0,0 +1,299 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/databasemigrationservice/types"
+	"github.com/eljosho/dms-manager/internal/tui"
+	"github.com/eljosho/dms-manager/pkg/dms"
+	"github.com/spf13/cobra"
+)
+
+// settingsPollInterval is how often settings set checks on a task it stopped
+// or modified
+const settingsPollInterval = 5 * time.Second
+
+// settingsModifyGrace is how long settings set keeps waiting for modified
+// settings to show up before it trusts the task's status alone
+const settingsModifyGrace = 15 * time.Second
+
+var (
+	settingsOutput   string
+	settingsFile     string
+	settingsResume   bool
+	settingsDryRun   bool
+	settingsAssumeOK bool
+	settingsTimeout  time.Duration
+)
+
+var settingsCmd = &cobra.Command{
+	Use:   "settings",
+	Short: "Inspect, compare and change task settings",
+	Long: `Inspect, compare and change the settings of DMS replication tasks: LOB
+handling, logging, validation, error handling and so on.
+
+Examples:
+  dms-manager settings get prod-orders
+  dms-manager settings get prod-orders -o yaml
+  dms-manager settings diff prod-orders staging-orders
+  dms-manager settings set prod-orders --file patch.json --resume`,
+}
+
+var settingsGetCmd = &cobra.Command{
+	Use:   "get [task-arn-or-name]",
+	Short: "Print the settings of a task",
+	Args:  cobra.ExactArgs(1),
+	Run:   runSettingsGet,
+}
+
+var settingsDiffCmd = &cobra.Command{
+	Use:   "diff [task-a] [task-b]",
+	Short: "Compare the settings of two tasks",
+	Long: `Compare the settings of two tasks setting by setting. Each difference is
+listed with its path, e.g. Logging.EnableLogging, and the values of both
+tasks. The command exits with status 1 if the settings differ.`,
+	Args: cobra.ExactArgs(2),
+	Run:  runSettingsDiff,
+}
+
+var settingsSetCmd = &cobra.Command{
+	Use:   "set [task-arn-or-name]",
+	Short: "Change the settings of a task with a JSON merge patch",
+	Long: `Merge a JSON patch into the settings of a task and apply them.
+
+The patch follows RFC 7386: objects are merged member by member, null removes
+a setting and any other value replaces it. For example, to turn on logging
+and validation:
+
+  {"Logging": {"EnableLogging": true}, "ValidationSettings": {"EnableValidation": true}}
+
+DMS only modifies tasks that are not running. A running task is stopped
+first, after confirmation, and resumed afterwards with --resume. The changes
+are listed before anything is applied; --dry-run stops there.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runSettingsSet,
+}
+
+func init() {
+	settingsGetCmd.Flags().StringVarP(&settingsOutput, "output", "o", "json", "Output format: json or yaml")
+	settingsSetCmd.Flags().StringVarP(&settingsFile, "file", "f", "", "JSON merge patch to apply (- reads stdin)")
+	settingsSetCmd.Flags().BoolVar(&settingsResume, "resume", false, "Resume the task afterwards if it had to be stopped")
+	settingsSetCmd.Flags().BoolVar(&settingsDryRun, "dry-run", false, "Only list the changes")
+	settingsSetCmd.Flags().BoolVarP(&settingsAssumeOK, "yes", "y", false, "Skip the confirmation")
+	settingsSetCmd.Flags().DurationVar(&settingsTimeout, "timeout", 10*time.Minute, "How long to wait for the task to stop and for the modification to finish")
+	settingsSetCmd.MarkFlagRequired("file")
+	settingsCmd.AddCommand(settingsGetCmd, settingsDiffCmd, settingsSetCmd)
+	rootCmd.AddCommand(settingsCmd)
+}
+
+func runSettingsGet(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	if settingsOutput != "json" && settingsOutput != "yaml" {
+		exitWithError(fmt.Errorf("invalid output format %q (use json or yaml)", settingsOutput))
+	}
+
+	client, err := dms.NewClient(ctx, GetProfile(), GetRegion())
+	if err != nil {
+		exitWithError(fmt.Errorf("failed to create DMS client: %w", err))
+	}
+
+	task := resolveTask(ctx, client, args[0])
+	if task.Settings == "" {
+		exitWithError(fmt.Errorf("task %s has no settings", task.Name))
+	}
+
+	var out string
+	if settingsOutput == "yaml" {
+		out, err = dms.JSONToYAML(task.Settings)
+	} else {
+		out, err = dms.PrettyJSON(task.Settings)
+		out += "\n"
+	}
+	if err != nil {
+		exitWithError(err)
+	}
+	fmt.Print(out)
+}
+
+func runSettingsDiff(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	client, err := dms.NewClient(ctx, GetProfile(), GetRegion())
+	if err != nil {
+		exitWithError(fmt.Errorf("failed to create DMS client: %w", err))
+	}
+
+	a := resolveTask(ctx, client, args[0])
+	b := resolveTask(ctx, client, args[1])
+
+	changes, err := dms.DiffSettings(a.Settings, b.Settings)
+	if err != nil {
+		exitWithError(err)
+	}
+	if len(changes) == 0 {
+		fmt.Printf("✓ %s and %s have the same settings\n", a.Name, b.Name)
+		return
+	}
+
+	fmt.Printf("%s %s\n", tui.CLIErrorStyle.Render("--- "+a.Name), tui.CLISuccessStyle.Render("+++ "+b.Name))
+	printSettingsChanges(changes)
+	fmt.Printf("\n%d setting(s) differ\n", len(changes))
+	os.Exit(1)
+}
+
+func runSettingsSet(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	patch, err := readSettingsPatch(settingsFile)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	client, err := dms.NewClient(ctx, GetProfile(), GetRegion())
+	if err != nil {
+		exitWithError(fmt.Errorf("failed to create DMS client: %w", err))
+	}
+
+	task := resolveTask(ctx, client, args[0])
+
+	merged, err := dms.MergeSettings(task.Settings, patch)
+	if err != nil {
+		exitWithError(err)
+	}
+	before := task.Settings
+	if strings.TrimSpace(before) == "" {
+		before = "{}"
+	}
+	changes, err := dms.DiffSettings(before, merged)
+	if err != nil {
+		exitWithError(err)
+	}
+	if len(changes) == 0 {
+		fmt.Printf("✓ %s: the patch changes nothing\n", task.Name)
+		return
+	}
+
+	fmt.Printf("Changing %d setting(s) of %s:\n", len(changes), tui.CLIPrimaryStyle.Render(task.Name))
+	printSettingsChanges(changes)
+	fmt.Println()
+
+	if settingsDryRun {
+		return
+	}
+
+	mustStop := false
+	switch strings.ToLower(task.Status) {
+	case "stopped", "ready", "failed":
+	case "running":
+		mustStop = true
+	default:
+		exitWithError(fmt.Errorf("task %s is %s; try again once it is stopped or running", task.Name, task.Status))
+	}
+
+	question := "Apply?"
+	if mustStop {
+		question = fmt.Sprintf("%s is running and will be stopped to apply the settings. Proceed?", task.Name)
+	}
+	if !settingsAssumeOK && !confirm(question) {
+		exitWithError(fmt.Errorf("aborted"))
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, settingsTimeout)
+	defer cancel()
+
+	if mustStop {
+		fmt.Printf("Stopping %s...\n", task.Name)
+		if err := client.StopTask(ctx, task.ARN); err != nil {
+			exitWithError(err)
+		}
+		if _, err := client.WaitForTaskStatus(waitCtx, task.ARN, settingsPollInterval, "stopped", "failed"); err != nil {
+			exitWithError(err)
+		}
+	}
+
+	if err := client.ModifyTaskSettings(ctx, task.ARN, merged); err != nil {
+		exitWithError(err)
+	}
+	fmt.Printf("Modifying %s...\n", task.Name)
+	modified, err := client.WaitForTaskSettings(waitCtx, task.ARN, settingsPollInterval, patch, settingsModifyGrace)
+	if err != nil {
+		exitWithError(err)
+	}
+	// A task that was failed before stays failed after a good modification
+	if applied, _ := dms.SettingsApplied(modified.Settings, patch); !applied && strings.EqualFold(modified.Status, "failed") {
+		err := fmt.Errorf("task %s failed while modifying its settings", task.Name)
+		if modified.LastFailureMessage != "" {
+			err = fmt.Errorf("%w: %s", err, modified.LastFailureMessage)
+		}
+		exitWithError(err)
+	}
+	fmt.Printf("✓ %s: settings updated\n", task.Name)
+
+	if !mustStop {
+		return
+	}
+	if !settingsResume {
+		fmt.Printf("\n%s was left stopped; resume it with: dms-manager resume %s\n", task.Name, task.Name)
+		return
+	}
+	if err := client.StartTask(ctx, task.ARN, types.StartReplicationTaskTypeValueResumeProcessing, dms.CDCOptions{}); err != nil {
+		exitWithError(err)
+	}
+	fmt.Printf("✓ %s: resumed\n", task.Name)
+}
+
+// resolveTask looks up the single task an argument names, exiting if it
+// names none or several
+func resolveTask(ctx context.Context, client *dms.Client, arg string) *dms.Task {
+	taskARNs, err := resolveTaskARNs(ctx, client, []string{arg})
+	if err != nil {
+		exitWithError(err)
+	}
+	if len(taskARNs) != 1 {
+		exitWithError(fmt.Errorf("%q must name exactly one task, got %d", arg, len(taskARNs)))
+	}
+
+	task, err := client.DescribeTask(ctx, taskARNs[0])
+	if err != nil {
+		exitWithError(err)
+	}
+	return task
+}
+
+// readSettingsPatch reads a patch file, or stdin for "-"
+func readSettingsPatch(path string) (string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read settings patch: %w", err)
+	}
+	return string(data), nil
+}
+
+// printSettingsChanges lists settings differences like a diff: removed
+// settings with -, added ones with + and changed ones with ~
+func printSettingsChanges(changes []dms.SettingsChange) {
+	for _, c := range changes {
+		switch {
+		case !c.HasNew:
+			fmt.Printf("  %s %s\n", tui.CLIErrorStyle.Render("- "+c.Path+":"), tui.CLIMutedStyle.Render(dms.FormatSettingsValue(c.Old)))
+		case !c.HasOld:
+			fmt.Printf("  %s %s\n", tui.CLISuccessStyle.Render("+ "+c.Path+":"), tui.CLIValueStyle.Render(dms.FormatSettingsValue(c.New)))
+		default:
+			fmt.Printf("  %s %s → %s\n", tui.CLIWarningStyle.Render("~ "+c.Path+":"),
+				tui.CLIMutedStyle.Render(dms.FormatSettingsValue(c.Old)),
+				tui.CLIValueStyle.Render(dms.FormatSettingsValue(c.New)))
+		}
+	}
+}
@@ -28,13 +28,22 @@ Note: When using wildcards, you MUST quote the argument to prevent shell expansi
 Examples:
   dms-manager start task1 task2
   dms-manager start "*-database"
-  dms-manager start "prod-*" --type resume-processing`,
+  dms-manager start "prod-*" --type resume-processing
+  dms-manager start "prod-*" --preflight
+  dms-manager start cdc-task --cdc-start-time 2024-05-01T10:00:00Z
+  dms-manager start cdc-task --cdc-start-position 0/16B3748 --cdc-stop-position commit_time:2024-05-01T12:00:00
+
+CDC start times and positions only apply to cdc tasks; a stop position applies
+to any task that replicates changes. Starting with CDC options lists the tasks
+and asks for confirmation unless --yes is given.`,
 	Args: cobra.MinimumNArgs(1),
 	Run:  runStart,
 }
 
 func init() {
 	startCmd.Flags().StringVarP(&startType, "type", "t", "start-replication", "Start type: start-replication, resume-processing, or reload-target")
+	addPreflightFlags(startCmd)
+	addCDCFlags(startCmd)
 	rootCmd.AddCommand(startCmd)
 }
 
@@ -69,9 +78,15 @@ func runStart(cmd *cobra.Command, args []string) {
 		exitWithError(fmt.Errorf("invalid start type: %s (use start-replication, resume-processing, or reload-target)", startType))
 	}
 
+	cdc := cdcOptions(ctx, cmd, client, taskARNs)
+
+	requested := len(taskARNs)
+	taskARNs = preflightTasks(ctx, cmd, client, taskARNs)
+	confirmCDC(cmd, "Starting", taskARNs, cdc)
+
 	fmt.Printf("Starting %d task(s) in parallel...\n\n", len(taskARNs))
 
-	results := client.StartTasks(ctx, taskARNs, taskStartType)
+	results := client.StartTasksAt(ctx, taskARNs, taskStartType, cdc.forTask)
 
 	// Print results
 	successCount := 0
@@ -84,5 +99,5 @@ func runStart(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	fmt.Printf("\nSuccessfully started %d out of %d tasks\n", successCount, len(taskARNs))
+	fmt.Printf("\nSuccessfully started %d out of %d tasks\n", successCount, requested)
 }
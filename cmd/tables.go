@@ -0,0 +1,431 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/eljosho/dms-manager/internal/tui"
+	"github.com/eljosho/dms-manager/pkg/dms"
+	"github.com/spf13/cobra"
+)
+
+var tablesCmd = &cobra.Command{
+	Use:   "tables [task-arn-or-name...]",
+	Short: "Analyze table statistics across tasks",
+	Long: `Fetch the table statistics of several tasks at once, all tasks if none are
+given, and list, filter, sort and total them across tasks.
+
+--where takes field<op>value conditions, all of which must hold. Text fields
+(task, schema, table, state, validation) take = and != and match parts of the
+value, ignoring case, or == and match the whole value. Counters (rows, errors,
+inserts, updates, deletes, ddls, changes, applied, pending, failed, suspended)
+also take >, >=, < and <=. DMS applies schema==, table== and state== itself,
+so only the matching tables are fetched.
+
+Tables that more than one task loads into the same target endpoint are
+reported, as the tasks overwrite each other's rows. They can't be found among
+the tables of a narrowed fetch, so they aren't looked for then.
+
+Wildcards are supported for task names (e.g. "prod-*", "*-database").
+
+Examples:
+  dms-manager tables "prod-*" --where state=error
+  dms-manager tables "prod-*" --where failed>0
+  dms-manager tables "prod-*" --where schema==billing --where "state==Table error"
+  dms-manager tables --sort rows --top 20
+  dms-manager tables "prod-*" --by-schema
+  dms-manager tables "prod-*" --where validation=mismatched -o json`,
+	Run: runTables,
+}
+
+func init() {
+	tablesCmd.Flags().StringArray("where", nil, "Only list tables meeting this condition, e.g. state=error or rows>1000000 (repeatable)")
+	tablesCmd.Flags().String("sort", "", "Sort by a field: counters largest first, text fields alphabetically")
+	tablesCmd.Flags().Int("top", 0, "Only list the first N tables or schemas")
+	tablesCmd.Flags().Bool("by-schema", false, "Total the tables per schema instead of listing them")
+	tablesCmd.Flags().StringP("output", "o", "table", "Output format: table, json or csv")
+	rootCmd.AddCommand(tablesCmd)
+}
+
+// tableJSON is a table in the structured output of the tables command
+type tableJSON struct {
+	Task                       string `json:"task"`
+	TaskARN                    string `json:"taskArn"`
+	Schema                     string `json:"schema"`
+	Table                      string `json:"table"`
+	State                      string `json:"state"`
+	FullLoadRows               int64  `json:"fullLoadRows"`
+	FullLoadErrorRows          int64  `json:"fullLoadErrorRows"`
+	FullLoadCondtnlChkFailed   int64  `json:"fullLoadCondtnlChkFailedRows"`
+	Inserts                    int64  `json:"inserts"`
+	Updates                    int64  `json:"updates"`
+	Deletes                    int64  `json:"deletes"`
+	Ddls                       int64  `json:"ddls"`
+	AppliedInserts             int64  `json:"appliedInserts"`
+	AppliedUpdates             int64  `json:"appliedUpdates"`
+	AppliedDeletes             int64  `json:"appliedDeletes"`
+	ValidationState            string `json:"validationState"`
+	ValidationPendingRecords   int64  `json:"validationPendingRecords"`
+	ValidationFailedRecords    int64  `json:"validationFailedRecords"`
+	ValidationSuspendedRecords int64  `json:"validationSuspendedRecords"`
+}
+
+// schemaJSON is a schema total in the structured output of the tables command
+type schemaJSON struct {
+	Schema                  string   `json:"schema"`
+	Tasks                   []string `json:"tasks"`
+	Tables                  int      `json:"tables"`
+	ErroredTables           int      `json:"erroredTables"`
+	FullLoadRows            int64    `json:"fullLoadRows"`
+	FullLoadErrorRows       int64    `json:"fullLoadErrorRows"`
+	Changes                 int64    `json:"changes"`
+	ValidationFailedRecords int64    `json:"validationFailedRecords"`
+}
+
+// overlapJSON is a table loaded by several tasks in the structured output
+type overlapJSON struct {
+	Schema         string   `json:"schema"`
+	Table          string   `json:"table"`
+	TargetEndpoint string   `json:"targetEndpoint"`
+	Tasks          []string `json:"tasks"`
+}
+
+// tablesReport is the structured output of the tables command
+type tablesReport struct {
+	Tables   []tableJSON       `json:"tables,omitempty"`
+	Schemas  []schemaJSON      `json:"schemas,omitempty"`
+	Overlaps []overlapJSON     `json:"overlaps"`              // null if not looked for
+	Errors   map[string]string `json:"failedTasks,omitempty"` // task name to error
+}
+
+func runTables(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	output, _ := cmd.Flags().GetString("output")
+	if output != "table" && output != "json" && output != "csv" {
+		exitWithError(fmt.Errorf("invalid output format %q (use table, json or csv)", output))
+	}
+	where, _ := cmd.Flags().GetStringArray("where")
+	var conditions []dms.TableCondition
+	for _, w := range where {
+		c, err := dms.ParseTableCondition(w)
+		if err != nil {
+			exitWithError(err)
+		}
+		conditions = append(conditions, c)
+	}
+	sortBy, _ := cmd.Flags().GetString("sort")
+	sortBy = strings.ToLower(sortBy)
+	if err := dms.ValidateTableSort(sortBy); err != nil {
+		exitWithError(err)
+	}
+	top, _ := cmd.Flags().GetInt("top")
+	bySchema, _ := cmd.Flags().GetBool("by-schema")
+
+	client, err := dms.NewClient(ctx, GetProfile(), GetRegion())
+	if err != nil {
+		exitWithError(fmt.Errorf("failed to create DMS client: %w", err))
+	}
+
+	tasks, err := client.ListTasks(ctx)
+	if err != nil {
+		exitWithError(err)
+	}
+	tasksByARN := make(map[string]dms.Task, len(tasks))
+	taskARNs := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		tasksByARN[task.ARN] = task
+		taskARNs = append(taskARNs, task.ARN)
+	}
+	if len(args) > 0 {
+		if taskARNs, err = resolveTaskARNs(ctx, client, args); err != nil {
+			exitWithError(err)
+		}
+	}
+	if len(taskARNs) == 0 {
+		exitWithError(fmt.Errorf("no valid tasks found"))
+	}
+
+	// Fetch the statistics of all tasks in parallel, letting DMS apply the
+	// conditions it can
+	filter := dms.ServerTableFilter(conditions)
+	var all []dms.TaskTable
+	report := tablesReport{}
+	for _, r := range client.GetTableStatisticsBatchFiltered(ctx, taskARNs, filter) {
+		task, ok := tasksByARN[r.TaskARN]
+		if !ok {
+			task = dms.Task{ARN: r.TaskARN, Name: getTaskNameFromARN(r.TaskARN)}
+		}
+		if r.Error != nil {
+			if report.Errors == nil {
+				report.Errors = make(map[string]string)
+			}
+			report.Errors[task.Name] = r.Error.Error()
+			fmt.Fprintf(os.Stderr, "%s %s: %v\n", tui.CLIWarningStyle.Render("Warning:"), task.Name, r.Error)
+			continue
+		}
+		for _, s := range r.Stats {
+			all = append(all, dms.TaskTable{TaskARN: task.ARN, TaskName: task.Name, TargetEndpointARN: task.TargetEndpointARN, TableStatistic: s})
+		}
+	}
+	if len(report.Errors) == len(taskARNs) {
+		exitWithError(fmt.Errorf("failed to get table statistics of any task"))
+	}
+
+	// Overlaps are looked for among all tables, whatever the other
+	// conditions, but only if all tables were fetched
+	var overlaps []dms.TableOverlap
+	if filter.IsZero() {
+		overlaps = dms.FindOverlaps(all)
+		report.Overlaps = []overlapJSON{}
+	}
+
+	var tables []dms.TaskTable
+	for _, t := range all {
+		if matchesAll(t, conditions) {
+			tables = append(tables, t)
+		}
+	}
+	dms.SortTaskTables(tables, sortBy)
+
+	var schemas []dms.SchemaSummary
+	if bySchema {
+		schemas = dms.SummarizeBySchema(tables, sortBy)
+		if top > 0 && len(schemas) > top {
+			schemas = schemas[:top]
+		}
+	} else if top > 0 && len(tables) > top {
+		tables = tables[:top]
+	}
+
+	// Endpoint names are a nicety; fall back to ARNs if they can't be listed
+	endpointsByARN := map[string]dms.Endpoint{}
+	if len(overlaps) > 0 {
+		if endpoints, err := client.ListEndpoints(ctx); err == nil {
+			endpointsByARN = dms.EndpointsByARN(endpoints)
+		}
+	}
+
+	switch output {
+	case "json":
+		if !bySchema {
+			for _, t := range tables {
+				report.Tables = append(report.Tables, tableToJSON(t))
+			}
+		}
+		for _, s := range schemas {
+			report.Schemas = append(report.Schemas, schemaToJSON(s))
+		}
+		for _, o := range overlaps {
+			report.Overlaps = append(report.Overlaps, overlapJSON{
+				Schema:         o.SchemaName,
+				Table:          o.TableName,
+				TargetEndpoint: endpointLabel(o.TargetEndpointARN, endpointsByARN),
+				Tasks:          o.Tasks,
+			})
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			exitWithError(err)
+		}
+	case "csv":
+		if bySchema {
+			writeSchemasCSV(schemas)
+		} else {
+			writeTablesCSV(tables)
+		}
+	default:
+		if bySchema {
+			printSchemaSummaries(schemas)
+		} else {
+			printTaskTables(tables)
+		}
+		if filter.IsZero() {
+			printOverlaps(overlaps, endpointsByARN)
+		} else {
+			fmt.Println("\n" + tui.CLIMutedStyle.Render("Tables loaded by more than one task aren't looked for when schema==, table== or state== narrow the fetch."))
+		}
+	}
+}
+
+// matchesAll reports whether a table meets all conditions
+func matchesAll(t dms.TaskTable, conditions []dms.TableCondition) bool {
+	for _, c := range conditions {
+		if !c.Matches(t) {
+			return false
+		}
+	}
+	return true
+}
+
+func printTaskTables(tables []dms.TaskTable) {
+	if len(tables) == 0 {
+		fmt.Println(tui.CLIWarningStyle.Render("No tables found."))
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	headers := []string{"TASK", "SCHEMA", "TABLE", "STATE", "ROWS", "ERRORS", "CHANGES", "VALIDATION"}
+	var coloredHeaders []string
+	var coloredSeps []string
+	for _, h := range headers {
+		coloredHeaders = append(coloredHeaders, tui.CLIHeaderStyle.Render(h))
+		coloredSeps = append(coloredSeps, tui.CLIMutedStyle.Render(strings.Repeat("─", len(h))))
+	}
+	fmt.Fprintln(w, strings.Join(coloredHeaders, "\t"))
+	fmt.Fprintln(w, strings.Join(coloredSeps, "\t"))
+
+	for _, t := range tables {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			tui.CLIPrimaryStyle.Render(t.TaskName),
+			tui.CLIValueStyle.Render(t.SchemaName),
+			tui.CLIValueStyle.Render(t.TableName),
+			getTableStateStyle(t.TableState).Render(valueOrDash(t.TableState)),
+			tui.CLINumberStyle.Render(fmt.Sprintf("%d", t.FullLoadRows)),
+			formatTableErrors(t.TableStatistic),
+			tui.CLINumberStyle.Render(fmt.Sprintf("%d", t.Inserts+t.Updates+t.Deletes)),
+			formatTableValidation(t.TableStatistic),
+		)
+	}
+	w.Flush()
+
+	fmt.Printf("\n%s %s\n", tui.CLILabelStyle.Render("Total tables:"), tui.CLINumberStyle.Render(fmt.Sprintf("%d", len(tables))))
+}
+
+func printSchemaSummaries(schemas []dms.SchemaSummary) {
+	if len(schemas) == 0 {
+		fmt.Println(tui.CLIWarningStyle.Render("No tables found."))
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	headers := []string{"SCHEMA", "TASKS", "TABLES", "ERRORED", "ROWS", "ERRORS", "CHANGES", "FAILED VALIDATION"}
+	var coloredHeaders []string
+	var coloredSeps []string
+	for _, h := range headers {
+		coloredHeaders = append(coloredHeaders, tui.CLIHeaderStyle.Render(h))
+		coloredSeps = append(coloredSeps, tui.CLIMutedStyle.Render(strings.Repeat("─", len(h))))
+	}
+	fmt.Fprintln(w, strings.Join(coloredHeaders, "\t"))
+	fmt.Fprintln(w, strings.Join(coloredSeps, "\t"))
+
+	for _, s := range schemas {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			tui.CLIPrimaryStyle.Render(s.Schema),
+			tui.CLIValueStyle.Render(strings.Join(s.Tasks, ", ")),
+			tui.CLINumberStyle.Render(fmt.Sprintf("%d", s.Tables)),
+			getDescribeErrorCountStyle(int64(s.ErroredTables)).Render(fmt.Sprintf("%d", s.ErroredTables)),
+			tui.CLINumberStyle.Render(fmt.Sprintf("%d", s.Totals.FullLoadRows)),
+			formatTableErrors(s.Totals),
+			tui.CLINumberStyle.Render(fmt.Sprintf("%d", s.Totals.Inserts+s.Totals.Updates+s.Totals.Deletes)),
+			getDescribeErrorCountStyle(s.Totals.ValidationFailedRecords).Render(fmt.Sprintf("%d", s.Totals.ValidationFailedRecords)),
+		)
+	}
+	w.Flush()
+
+	fmt.Printf("\n%s %s\n", tui.CLILabelStyle.Render("Total schemas:"), tui.CLINumberStyle.Render(fmt.Sprintf("%d", len(schemas))))
+}
+
+// printOverlaps warns about tables loaded into the same target by several
+// tasks
+func printOverlaps(overlaps []dms.TableOverlap, endpoints map[string]dms.Endpoint) {
+	if len(overlaps) == 0 {
+		return
+	}
+
+	fmt.Println("\n" + tui.CLIWarningStyle.Render(fmt.Sprintf("⚠ %d table(s) loaded by more than one task:", len(overlaps))))
+	for _, o := range overlaps {
+		fmt.Printf("  %s %s %s\n",
+			tui.CLIPrimaryStyle.Render(o.SchemaName+"."+o.TableName),
+			tui.CLIMutedStyle.Render("into "+endpointLabel(o.TargetEndpointARN, endpoints)+" by"),
+			tui.CLIValueStyle.Render(strings.Join(o.Tasks, ", ")))
+	}
+}
+
+// endpointLabel names an endpoint by identifier and engine, or by ARN if it
+// is unknown
+func endpointLabel(arn string, endpoints map[string]dms.Endpoint) string {
+	if ep, ok := endpoints[arn]; ok {
+		return ep.Label()
+	}
+	return arn
+}
+
+func tableToJSON(t dms.TaskTable) tableJSON {
+	return tableJSON{
+		Task:                       t.TaskName,
+		TaskARN:                    t.TaskARN,
+		Schema:                     t.SchemaName,
+		Table:                      t.TableName,
+		State:                      t.TableState,
+		FullLoadRows:               t.FullLoadRows,
+		FullLoadErrorRows:          t.FullLoadErrorRows,
+		FullLoadCondtnlChkFailed:   t.FullLoadCondtnlChkFailedRows,
+		Inserts:                    t.Inserts,
+		Updates:                    t.Updates,
+		Deletes:                    t.Deletes,
+		Ddls:                       t.Ddls,
+		AppliedInserts:             t.AppliedInserts,
+		AppliedUpdates:             t.AppliedUpdates,
+		AppliedDeletes:             t.AppliedDeletes,
+		ValidationState:            t.ValidationState,
+		ValidationPendingRecords:   t.ValidationPendingRecords,
+		ValidationFailedRecords:    t.ValidationFailedRecords,
+		ValidationSuspendedRecords: t.ValidationSuspendedRecords,
+	}
+}
+
+func schemaToJSON(s dms.SchemaSummary) schemaJSON {
+	return schemaJSON{
+		Schema:                  s.Schema,
+		Tasks:                   s.Tasks,
+		Tables:                  s.Tables,
+		ErroredTables:           s.ErroredTables,
+		FullLoadRows:            s.Totals.FullLoadRows,
+		FullLoadErrorRows:       s.Totals.FullLoadErrorRows + s.Totals.FullLoadCondtnlChkFailedRows,
+		Changes:                 s.Totals.Inserts + s.Totals.Updates + s.Totals.Deletes,
+		ValidationFailedRecords: s.Totals.ValidationFailedRecords,
+	}
+}
+
+func writeTablesCSV(tables []dms.TaskTable) {
+	w := csv.NewWriter(os.Stdout)
+	w.Write([]string{"task", "schema", "table", "state", "full_load_rows", "full_load_error_rows", "inserts", "updates", "deletes", "ddls",
+		"applied_inserts", "applied_updates", "applied_deletes", "validation_state", "validation_pending", "validation_failed", "validation_suspended"})
+	for _, t := range tables {
+		w.Write([]string{t.TaskName, t.SchemaName, t.TableName, t.TableState,
+			itoa(t.FullLoadRows), itoa(t.FullLoadErrorRows + t.FullLoadCondtnlChkFailedRows),
+			itoa(t.Inserts), itoa(t.Updates), itoa(t.Deletes), itoa(t.Ddls),
+			itoa(t.AppliedInserts), itoa(t.AppliedUpdates), itoa(t.AppliedDeletes),
+			t.ValidationState, itoa(t.ValidationPendingRecords), itoa(t.ValidationFailedRecords), itoa(t.ValidationSuspendedRecords)})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		exitWithError(err)
+	}
+}
+
+func writeSchemasCSV(schemas []dms.SchemaSummary) {
+	w := csv.NewWriter(os.Stdout)
+	w.Write([]string{"schema", "tasks", "tables", "errored_tables", "full_load_rows", "full_load_error_rows", "changes", "validation_failed"})
+	for _, s := range schemas {
+		w.Write([]string{s.Schema, strings.Join(s.Tasks, ";"), strconv.Itoa(s.Tables), strconv.Itoa(s.ErroredTables),
+			itoa(s.Totals.FullLoadRows), itoa(s.Totals.FullLoadErrorRows + s.Totals.FullLoadCondtnlChkFailedRows),
+			itoa(s.Totals.Inserts + s.Totals.Updates + s.Totals.Deletes), itoa(s.Totals.ValidationFailedRecords)})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		exitWithError(err)
+	}
+}
+
+func itoa(n int64) string {
+	return strconv.FormatInt(n, 10)
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/eljosho/dms-manager/internal/tui"
+	"github.com/eljosho/dms-manager/pkg/dms"
+	"github.com/spf13/cobra"
+)
+
+var throughputCmd = &cobra.Command{
+	Use:   "throughput [task-arn-or-name...]",
+	Short: "Watch replication throughput of DMS tasks",
+	Long: `Poll table statistics of one or more DMS replication tasks and report
+full load rows/sec and change (insert/update/delete) rates/sec derived from
+successive polls, with a sparkline of recent activity.
+
+Tasks whose throughput dropped to zero after having been active are flagged
+as stalled. Rates appear from the second poll onwards.
+
+Wildcards are supported for task names (e.g. "prod-*", "*-database").
+Note: When using wildcards, you MUST quote the argument to prevent shell expansion.
+
+Examples:
+  dms-manager throughput task1 task2
+  dms-manager throughput "prod-*" --interval 30s
+  dms-manager throughput task1 --tables --samples 10`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runThroughput,
+}
+
+func init() {
+	addRefreshFlags(throughputCmd, 10*time.Second)
+	throughputCmd.Flags().Int("samples", 0, "Stop after this many polls (default: run until interrupted)")
+	throughputCmd.Flags().Bool("tables", false, "Show per-table rates")
+	rootCmd.AddCommand(throughputCmd)
+}
+
+func runThroughput(cmd *cobra.Command, args []string) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	client, err := dms.NewClient(ctx, GetProfile(), GetRegion())
+	if err != nil {
+		exitWithError(fmt.Errorf("failed to create DMS client: %w", err))
+	}
+
+	// Resolve task names to ARNs
+	taskARNs, err := resolveTaskARNs(ctx, client, args)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if len(taskARNs) == 0 {
+		exitWithError(fmt.Errorf("no valid tasks found"))
+	}
+
+	samples, _ := cmd.Flags().GetInt("samples")
+	showTables, _ := cmd.Flags().GetBool("tables")
+
+	policy, err := refreshPolicy(cmd)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	tracker := dms.NewThroughputTracker(dms.DefaultThroughputWindow)
+	scheduler := dms.NewRefreshScheduler(policy)
+
+	for poll := 1; samples == 0 || poll <= samples; poll++ {
+		at := time.Now()
+		results := client.GetTableStatisticsBatch(ctx, taskARNs)
+		if ctx.Err() != nil {
+			return
+		}
+
+		failed, active := 0, false
+		for _, r := range results {
+			if r.Error != nil {
+				failed++
+				continue
+			}
+			tracker.Record(r.TaskARN, at, r.Stats)
+			if rate, ok := tracker.Task(r.TaskARN).Latest(); !ok || rate.Total() > 0 {
+				active = true
+			}
+		}
+		if failed == len(results) {
+			scheduler.Fail()
+		} else {
+			scheduler.Observe(false, active)
+		}
+
+		printThroughput(at, results, tracker, showTables)
+
+		if samples != 0 && poll == samples {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(scheduler.Next()):
+		}
+	}
+}
+
+func printThroughput(at time.Time, results []dms.TaskTableStatistics, tracker *dms.ThroughputTracker, showTables bool) {
+	fmt.Printf("%s %s\n", tui.CLILabelStyle.Render("Polled at:"), tui.CLIValueStyle.Render(at.Format("2006-01-02 15:04:05")))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	headers := []string{"TASK", "ROWS/S", "CHANGES/S", "TREND", ""}
+	var coloredHeaders []string
+	for _, h := range headers {
+		coloredHeaders = append(coloredHeaders, tui.CLIHeaderStyle.Render(h))
+	}
+	fmt.Fprintln(w, strings.Join(coloredHeaders, "\t"))
+
+	for _, r := range results {
+		name := getTaskNameFromARN(r.TaskARN)
+		if r.Error != nil {
+			fmt.Fprintf(w, "%s\t%s\n", tui.CLIPrimaryStyle.Render(name), tui.CLIErrorStyle.Render(r.Error.Error()))
+			continue
+		}
+
+		printThroughputRow(w, tui.CLIPrimaryStyle.Render(name), tracker.Task(r.TaskARN))
+
+		if showTables {
+			keys := make([]string, 0, len(r.Stats))
+			for _, s := range r.Stats {
+				keys = append(keys, s.SchemaName+"."+s.TableName)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				printThroughputRow(w, "  "+tui.CLIValueStyle.Render(key), tracker.Table(r.TaskARN, key))
+			}
+		}
+	}
+	w.Flush()
+	fmt.Println()
+}
+
+func printThroughputRow(w *tabwriter.Writer, label string, window *dms.ThroughputWindow) {
+	rate, ok := window.Latest()
+	if !ok {
+		fmt.Fprintf(w, "%s\t%s\n", label, tui.CLIMutedStyle.Render("collecting baseline..."))
+		return
+	}
+
+	flag := ""
+	if window.Stalled() {
+		flag = tui.CLIWarningStyle.Render("⚠ stalled")
+	}
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+		label,
+		tui.CLINumberStyle.Render(dms.FormatRate(rate.RowsPerSec)),
+		tui.CLINumberStyle.Render(dms.FormatRate(rate.ChangesPerSec)),
+		tui.CLIHeaderStyle.Render(dms.Sparkline(window.Totals(), 20)),
+		flag,
+	)
+}
@@ -17,8 +17,16 @@ var tuiCmd = &cobra.Command{
 	Run:   runTUI,
 }
 
+var (
+	tuiReadOnly bool
+	tuiNoMouse  bool
+)
+
 func init() {
 	rootCmd.AddCommand(tuiCmd)
+	tuiCmd.Flags().BoolVar(&tuiReadOnly, "read-only", false, "Disable task operations (start, stop, resume, reload)")
+	tuiCmd.Flags().BoolVar(&tuiNoMouse, "no-mouse", false, "Leave the mouse to the terminal, e.g. for selecting text")
+	addRefreshFlags(tuiCmd, dms.DefaultRefreshPolicy.Interval)
 }
 
 func runTUI(cmd *cobra.Command, args []string) {
@@ -29,7 +37,17 @@ func runTUI(cmd *cobra.Command, args []string) {
 		exitWithError(fmt.Errorf("failed to create DMS client: %w", err))
 	}
 
-	p := tea.NewProgram(tui.NewModel(client), tea.WithAltScreen())
+	policy, err := refreshPolicy(cmd)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	opts := tui.Options{ReadOnly: tuiReadOnly, Refresh: policy}
+	progOpts := []tea.ProgramOption{tea.WithAltScreen()}
+	if !tuiNoMouse {
+		progOpts = append(progOpts, tea.WithMouseCellMotion())
+	}
+	p := tea.NewProgram(tui.NewModel(client, opts), progOpts...)
 
 	if _, err := p.Run(); err != nil {
 		exitWithError(fmt.Errorf("TUI error: %w", err))
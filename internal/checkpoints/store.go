@@ -0,0 +1,146 @@
+package checkpoints
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// EnvPath names the environment variable that overrides the store path
+const EnvPath = "DMS_MANAGER_CHECKPOINTS"
+
+// Checkpoint is a saved CDC recovery checkpoint of a task. Versions count up
+// per task, starting at 1.
+type Checkpoint struct {
+	TaskARN            string    `json:"taskArn"`
+	TaskName           string    `json:"taskName"`
+	Version            int       `json:"version"`
+	RecoveryCheckpoint string    `json:"recoveryCheckpoint"`
+	CdcStartPosition   string    `json:"cdcStartPosition,omitempty"`
+	Status             string    `json:"status"`
+	SavedAt            time.Time `json:"savedAt"`
+	Note               string    `json:"note,omitempty"`
+}
+
+// Store holds saved checkpoints in a JSON file
+type Store struct {
+	path        string
+	Checkpoints []Checkpoint `json:"checkpoints"`
+}
+
+// Path returns the store path: $DMS_MANAGER_CHECKPOINTS if set, otherwise
+// dms-manager/checkpoints.json in the user config directory
+func Path() (string, error) {
+	if p := os.Getenv(EnvPath); p != "" {
+		return p, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config directory: %w", err)
+	}
+	return filepath.Join(dir, "dms-manager", "checkpoints.json"), nil
+}
+
+// Open reads the store. A missing file yields an empty store.
+func Open() (*Store, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	store := &Store{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint store: %w", err)
+	}
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint store %s: %w", path, err)
+	}
+	return store, nil
+}
+
+// Path returns the file the store is saved to
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Add records a checkpoint as the next version of its task and returns it
+func (s *Store) Add(cp Checkpoint) Checkpoint {
+	cp.Version = 1
+	for _, c := range s.Checkpoints {
+		if c.TaskARN == cp.TaskARN && c.Version >= cp.Version {
+			cp.Version = c.Version + 1
+		}
+	}
+	s.Checkpoints = append(s.Checkpoints, cp)
+	return cp
+}
+
+// List returns the checkpoints of the given tasks, or of all tasks if none
+// are given, by task name and newest first
+func (s *Store) List(taskARNs ...string) []Checkpoint {
+	wanted := make(map[string]bool, len(taskARNs))
+	for _, arn := range taskARNs {
+		wanted[arn] = true
+	}
+
+	var list []Checkpoint
+	for _, c := range s.Checkpoints {
+		if len(wanted) == 0 || wanted[c.TaskARN] {
+			list = append(list, c)
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].TaskName != list[j].TaskName {
+			return list[i].TaskName < list[j].TaskName
+		}
+		return list[i].Version > list[j].Version
+	})
+	return list
+}
+
+// Get returns a version of a task's checkpoint, or the latest one if version
+// is 0
+func (s *Store) Get(taskARN string, version int) (Checkpoint, bool) {
+	var found *Checkpoint
+	for i, c := range s.Checkpoints {
+		if c.TaskARN != taskARN {
+			continue
+		}
+		if (version == 0 && (found == nil || c.Version > found.Version)) || c.Version == version {
+			found = &s.Checkpoints[i]
+		}
+	}
+	if found == nil {
+		return Checkpoint{}, false
+	}
+	return *found, true
+}
+
+// Save writes the store, replacing the file atomically
+func (s *Store) Save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint store: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create checkpoint store directory: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write checkpoint store: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to write checkpoint store: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// EnvPath names the environment variable that overrides the config file path
+const EnvPath = "DMS_MANAGER_CONFIG"
+
+// Config holds user preferences read from the config file
+type Config struct {
+	// Theme is the name of a built-in color theme
+	Theme string `json:"theme,omitempty"`
+
+	// Colors overrides individual theme colors, keyed by color name
+	Colors map[string]string `json:"colors,omitempty"`
+
+	// Keys overrides the keys bound to TUI actions, keyed by action name
+	Keys map[string][]string `json:"keys,omitempty"`
+
+	// Notify configures notifications for task status transitions
+	Notify Notify `json:"notify,omitempty"`
+
+	// Refresh configures how often the TUI and watch commands poll
+	Refresh Refresh `json:"refresh,omitempty"`
+
+	// Lint configures the rules of the lint command
+	Lint Lint `json:"lint,omitempty"`
+}
+
+// Lint turns lint rules off, changes their severity or suppresses them for
+// some tasks. Rules are named by ID, e.g. "logging-disabled".
+type Lint struct {
+	// Disable lists rules that are not run at all
+	Disable []string `json:"disable,omitempty"`
+
+	// Severity overrides the severity of rules: "info", "warning" or "error"
+	Severity map[string]string `json:"severity,omitempty"`
+
+	// Ignore suppresses rules for tasks whose names match one of the
+	// patterns, where "*" matches any sequence of characters
+	Ignore map[string][]string `json:"ignore,omitempty"`
+}
+
+// Refresh holds refresh delays as Go duration strings such as "5s"
+type Refresh struct {
+	// Interval is the regular delay between refreshes
+	Interval string `json:"interval,omitempty"`
+
+	// Fast is the delay while tasks are starting or stopping
+	Fast string `json:"fast,omitempty"`
+
+	// Idle is the longest delay reached when nothing changes
+	Idle string `json:"idle,omitempty"`
+
+	// Adaptive enables the fast and idle delays (default true)
+	Adaptive *bool `json:"adaptive,omitempty"`
+}
+
+// Notify selects the task transitions that raise a notification and how
+type Notify struct {
+	// Bell rings the terminal bell
+	Bell bool `json:"bell,omitempty"`
+
+	// Desktop sends a desktop notification through the terminal: "osc9" or
+	// "osc777"
+	Desktop string `json:"desktop,omitempty"`
+
+	// Transitions are "from->to" status patterns where "*" matches any
+	// status, or "full-load-complete"
+	Transitions []string `json:"transitions,omitempty"`
+}
+
+// Path returns the config file path: $DMS_MANAGER_CONFIG if set, otherwise
+// dms-manager/config.json in the user config directory
+func Path() (string, error) {
+	if p := os.Getenv(EnvPath); p != "" {
+		return p, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config directory: %w", err)
+	}
+	return filepath.Join(dir, "dms-manager", "config.json"), nil
+}
+
+// Load reads the config file. A missing file yields an empty config.
+func Load() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return &cfg, nil
+}
@@ -0,0 +1,239 @@
+// Package lint checks task settings and table mappings against best-practice
+// rules. Rules register themselves with Register; Run applies them to tasks
+// and reports findings, honoring suppressions from the config file and from
+// task tags.
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/eljosho/dms-manager/internal/config"
+	"github.com/eljosho/dms-manager/pkg/dms"
+)
+
+// IgnoreTag is the task tag that suppresses rules for a task. Its value is a
+// comma-separated list of rule IDs, or "*" for all rules.
+const IgnoreTag = "dms-manager:lint-ignore"
+
+// Severity ranks findings
+type Severity int
+
+const (
+	Info Severity = iota
+	Warning
+	Error
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Error:
+		return "error"
+	case Warning:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+// MarshalText writes the severity by name in structured output
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// ParseSeverity parses "info", "warning" or "error"
+func ParseSeverity(s string) (Severity, error) {
+	switch strings.ToLower(s) {
+	case "info":
+		return Info, nil
+	case "warning":
+		return Warning, nil
+	case "error":
+		return Error, nil
+	}
+	return Info, fmt.Errorf("invalid severity %q (use info, warning or error)", s)
+}
+
+// Target is a task as rules see it, with its settings and table mappings
+// parsed
+type Target struct {
+	Task     dms.Task
+	Settings map[string]any     // nil if the task has no settings
+	Mappings *dms.TableMappings // nil if the task has no mappings
+	Tags     map[string]string
+}
+
+// NewTarget parses the settings and table mappings of a task
+func NewTarget(task dms.Task, tags map[string]string) (Target, error) {
+	t := Target{Task: task, Tags: tags}
+	if strings.TrimSpace(task.Settings) != "" {
+		if err := json.Unmarshal([]byte(task.Settings), &t.Settings); err != nil {
+			return t, fmt.Errorf("%s: failed to parse task settings: %w", task.Name, err)
+		}
+	}
+	if strings.TrimSpace(task.TableMappings) != "" {
+		mappings, err := dms.ParseTableMappings(task.TableMappings)
+		if err != nil {
+			return t, fmt.Errorf("%s: %w", task.Name, err)
+		}
+		t.Mappings = mappings
+	}
+	return t, nil
+}
+
+// Setting returns the setting at a dotted path such as
+// "Logging.EnableLogging"
+func (t Target) Setting(path string) (any, bool) {
+	var v any = t.Settings
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = obj[key]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// BoolSetting returns a boolean setting, or def if it is not set
+func (t Target) BoolSetting(path string, def bool) bool {
+	if v, ok := t.Setting(path); ok {
+		if b, ok := v.(bool); ok {
+			return b
+		}
+	}
+	return def
+}
+
+// StringSetting returns a string setting, or "" if it is not set
+func (t Target) StringSetting(path string) string {
+	v, _ := t.Setting(path)
+	s, _ := v.(string)
+	return s
+}
+
+// Rule is a best-practice check. Check returns a message for each problem it
+// finds in a task.
+type Rule struct {
+	ID          string
+	Description string
+	Severity    Severity
+	Check       func(t Target) []string
+}
+
+var registry = map[string]Rule{}
+
+// Register adds a rule. It panics if a rule with the same ID exists.
+func Register(r Rule) {
+	if _, ok := registry[r.ID]; ok {
+		panic("lint: rule registered twice: " + r.ID)
+	}
+	registry[r.ID] = r
+}
+
+// Rules returns the registered rules by ID
+func Rules() []Rule {
+	rules := make([]Rule, 0, len(registry))
+	for _, r := range registry {
+		rules = append(rules, r)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+	return rules
+}
+
+// Finding is a problem a rule found in a task. Suppressed names what
+// suppressed it, "config" or "tag", if anything did.
+type Finding struct {
+	Rule       string   `json:"rule"`
+	Severity   Severity `json:"severity"`
+	Task       string   `json:"task"`
+	TaskARN    string   `json:"taskArn"`
+	Message    string   `json:"message"`
+	Suppressed string   `json:"suppressed,omitempty"`
+}
+
+// Run applies the registered rules to the targets as configured, returning
+// findings ordered by task, severity (highest first) and rule
+func Run(targets []Target, cfg config.Lint) ([]Finding, error) {
+	for _, id := range cfg.Disable {
+		if _, ok := registry[id]; !ok {
+			return nil, fmt.Errorf("lint.disable: unknown rule %q", id)
+		}
+	}
+	severities := make(map[string]Severity, len(cfg.Severity))
+	for id, s := range cfg.Severity {
+		if _, ok := registry[id]; !ok {
+			return nil, fmt.Errorf("lint.severity: unknown rule %q", id)
+		}
+		sev, err := ParseSeverity(s)
+		if err != nil {
+			return nil, fmt.Errorf("lint.severity.%s: %w", id, err)
+		}
+		severities[id] = sev
+	}
+	for id := range cfg.Ignore {
+		if _, ok := registry[id]; !ok {
+			return nil, fmt.Errorf("lint.ignore: unknown rule %q", id)
+		}
+	}
+
+	var findings []Finding
+	for _, rule := range Rules() {
+		if slices.Contains(cfg.Disable, rule.ID) {
+			continue
+		}
+		severity := rule.Severity
+		if s, ok := severities[rule.ID]; ok {
+			severity = s
+		}
+
+		for _, t := range targets {
+			for _, msg := range rule.Check(t) {
+				findings = append(findings, Finding{
+					Rule:       rule.ID,
+					Severity:   severity,
+					Task:       t.Task.Name,
+					TaskARN:    t.Task.ARN,
+					Message:    msg,
+					Suppressed: suppressedBy(rule.ID, t, cfg),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Task != b.Task {
+			return a.Task < b.Task
+		}
+		if a.Severity != b.Severity {
+			return a.Severity > b.Severity
+		}
+		return a.Rule < b.Rule
+	})
+	return findings, nil
+}
+
+// suppressedBy reports whether the config or a tag of the task suppresses a
+// rule for it
+func suppressedBy(id string, t Target, cfg config.Lint) string {
+	for _, pattern := range cfg.Ignore[id] {
+		if ok, _ := path.Match(pattern, t.Task.Name); ok {
+			return "config"
+		}
+	}
+	if ids, ok := t.Tags[IgnoreTag]; ok {
+		for _, ignored := range strings.Split(ids, ",") {
+			if ignored = strings.TrimSpace(ignored); ignored == id || ignored == "*" {
+				return "tag"
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,107 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/databasemigrationservice/types"
+	"github.com/eljosho/dms-manager/pkg/dms"
+)
+
+// The built-in rules
+func init() {
+	Register(Rule{
+		ID:          "logging-disabled",
+		Description: "CloudWatch logging is off, so task failures leave no log to investigate",
+		Severity:    Warning,
+		Check: func(t Target) []string {
+			if t.Settings != nil && !t.BoolSetting("Logging.EnableLogging", false) {
+				return []string{"CloudWatch logging is disabled (Logging.EnableLogging)"}
+			}
+			return nil
+		},
+	})
+
+	Register(Rule{
+		ID:          "full-lob-mode",
+		Description: "Full LOB mode loads LOBs one piece at a time, which only slows down tables with many LOB rows",
+		Severity:    Info,
+		Check: func(t Target) []string {
+			if t.BoolSetting("TargetMetadata.SupportLobs", true) && t.BoolSetting("TargetMetadata.FullLobMode", false) {
+				return []string{"full LOB mode is on (TargetMetadata.FullLobMode); prefer limited LOB mode with a LobMaxSize above the largest LOB"}
+			}
+			return nil
+		},
+	})
+
+	Register(Rule{
+		ID:          "validation-disabled",
+		Description: "Without validation, rows that differ between source and target go unnoticed",
+		Severity:    Warning,
+		Check: func(t Target) []string {
+			if t.Settings != nil && !t.BoolSetting("ValidationSettings.EnableValidation", false) {
+				return []string{"data validation is disabled (ValidationSettings.EnableValidation)"}
+			}
+			return nil
+		},
+	})
+
+	Register(Rule{
+		ID:          "drop-tables-on-cdc",
+		Description: "Dropping target tables on a task that replicates changes loses the changes applied so far when it reloads",
+		Severity:    Error,
+		Check: func(t Target) []string {
+			mode := t.StringSetting("FullLoadSettings.TargetTablePrepMode")
+			migrationType := types.MigrationTypeValue(t.Task.MigrationType)
+			replicatesChanges := migrationType == types.MigrationTypeValueCdc || migrationType == types.MigrationTypeValueFullLoadAndCdc
+			if replicatesChanges && mode == "DROP_AND_CREATE" {
+				return []string{fmt.Sprintf("target tables are dropped and recreated (FullLoadSettings.TargetTablePrepMode) on a %s task", t.Task.MigrationType)}
+			}
+			return nil
+		},
+	})
+
+	Register(Rule{
+		ID:          "no-selection-rules",
+		Description: "A task without an include selection rule migrates nothing",
+		Severity:    Error,
+		Check: func(t Target) []string {
+			if t.Mappings == nil {
+				return []string{"the task has no table mappings"}
+			}
+			for _, r := range t.Mappings.RulesOfType("selection") {
+				if r.Action == "include" {
+					return nil
+				}
+			}
+			return []string{"the table mappings have no include selection rule"}
+		},
+	})
+
+	Register(Rule{
+		ID:          "include-all-tables",
+		Description: "Including every table of every schema also picks up tables added later by accident",
+		Severity:    Info,
+		Check: func(t Target) []string {
+			if t.Mappings == nil {
+				return nil
+			}
+			var problems []string
+			for _, r := range t.Mappings.RulesOfType("selection") {
+				if r.Action == "include" && locatorValue(r.ObjectLocator, "schema-name") == "%" && locatorValue(r.ObjectLocator, "table-name") == "%" {
+					problems = append(problems, fmt.Sprintf("selection rule %s includes every table of every schema", r.ID))
+				}
+			}
+			return problems
+		},
+	})
+}
+
+// locatorValue returns a field of a rule's object locator
+func locatorValue(locator []dms.KeyValue, key string) string {
+	for _, kv := range locator {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return ""
+}
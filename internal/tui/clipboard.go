@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type copiedMsg struct {
+	what string
+	text string // copied text to echo in the message, if any
+	err  error
+}
+
+// CopyCmd copies text to the system clipboard with an OSC 52 escape
+// sequence, which works over SSH in terminals that support it. Inside tmux or
+// screen the sequence is wrapped so it reaches the outer terminal. With echo
+// set the copied text is shown in the confirmation.
+func CopyCmd(text, what string, echo bool) tea.Cmd {
+	return func() tea.Msg {
+		seq := osc52.New(text)
+		switch {
+		case os.Getenv("TMUX") != "":
+			seq = seq.Tmux()
+		case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+			seq = seq.Screen()
+		}
+
+		if _, err := seq.WriteTo(os.Stderr); err != nil {
+			return copiedMsg{what: what, err: fmt.Errorf("failed to copy %s: %w", what, err)}
+		}
+		msg := copiedMsg{what: what}
+		if echo {
+			msg.text = text
+		}
+		return msg
+	}
+}
@@ -2,6 +2,10 @@ package tui
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/databasemigrationservice/types"
@@ -12,8 +16,14 @@ import (
 // Messages for async operations
 
 type tasksLoadedMsg struct {
-	tasks []dms.Task
-	err   error
+	client   *dms.Client // active client when the load started
+	merged   bool        // whether the load was for the merged view
+	tasks    []dms.Task
+	contexts map[string]string // task ARN -> context name, merged view only
+	warning  string            // contexts that failed to load in the merged view
+	failures []error           // errors of the contexts named in warning
+	took     time.Duration
+	err      error
 }
 
 type taskOperationCompleteMsg struct {
@@ -24,14 +34,105 @@ type errorMsg struct {
 	err error
 }
 
-const refreshInterval = 5 * time.Second
+type endpointsLoadedMsg struct {
+	client    *dms.Client
+	endpoints []dms.Endpoint
+	err       error
+}
 
 // LoadTasksCmd loads tasks asynchronously
 func LoadTasksCmd(client *dms.Client) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
+		start := time.Now()
 		tasks, err := client.ListTasks(ctx)
-		return tasksLoadedMsg{tasks: tasks, err: err}
+		return tasksLoadedMsg{client: client, tasks: tasks, err: err, took: time.Since(start)}
+	}
+}
+
+// LoadEndpointsCmd loads the endpoints used to show task endpoints by name
+func LoadEndpointsCmd(client *dms.Client) tea.Cmd {
+	return func() tea.Msg {
+		endpoints, err := client.ListEndpoints(context.Background())
+		return endpointsLoadedMsg{client: client, endpoints: endpoints, err: err}
+	}
+}
+
+// LoadMergedTasksCmd loads tasks from several contexts in parallel for the
+// merged view of the active client. Contexts that fail are reported as a
+// warning unless all fail.
+func LoadMergedTasksCmd(active *dms.Client, clients map[string]*dms.Client) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		start := time.Now()
+
+		names := make([]string, 0, len(clients))
+		for name := range clients {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		var wg sync.WaitGroup
+		results := make([][]dms.Task, len(names))
+		errs := make([]error, len(names))
+		for i, name := range names {
+			wg.Add(1)
+			go func(index int, client *dms.Client) {
+				defer wg.Done()
+				results[index], errs[index] = client.ListTasks(ctx)
+			}(i, clients[name])
+		}
+		wg.Wait()
+
+		msg := tasksLoadedMsg{client: active, merged: true, contexts: make(map[string]string), took: time.Since(start)}
+		var failed []string
+		for i, name := range names {
+			if errs[i] != nil {
+				failed = append(failed, fmt.Sprintf("%s: %v", name, errs[i]))
+				msg.failures = append(msg.failures, errs[i])
+				continue
+			}
+			for _, task := range results[i] {
+				msg.tasks = append(msg.tasks, task)
+				msg.contexts[task.ARN] = name
+			}
+		}
+
+		if len(failed) == len(names) {
+			msg.err = fmt.Errorf("failed to load any context: %s", strings.Join(failed, "; "))
+		} else if len(failed) > 0 {
+			msg.warning = "Failed to load " + strings.Join(failed, "; ")
+		}
+		return msg
+	}
+}
+
+type profilesLoadedMsg struct {
+	profiles []string
+	err      error
+}
+
+// LoadProfilesCmd reads the profile names from the shared AWS config files
+func LoadProfilesCmd() tea.Cmd {
+	return func() tea.Msg {
+		profiles, err := dms.ListProfiles()
+		return profilesLoadedMsg{profiles: profiles, err: err}
+	}
+}
+
+type contextClientMsg struct {
+	client *dms.Client
+	pin    bool
+	err    error
+}
+
+// NewClientCmd creates a DMS client for another profile and region, either to
+// switch to it or to pin it for the merged view
+func NewClientCmd(profile, region string, pin bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		client, err := dms.NewClient(ctx, profile, region)
+		return contextClientMsg{client: client, pin: pin, err: err}
 	}
 }
 
@@ -40,7 +141,7 @@ func StartTasksCmd(client *dms.Client, arns []string) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
 		// Default to start-replication type
-		results := client.StartTasks(ctx, arns, types.StartReplicationTaskTypeValueStartReplication)
+		results := client.StartTasks(ctx, arns, types.StartReplicationTaskTypeValueStartReplication, dms.CDCOptions{})
 		return taskOperationCompleteMsg{results: results}
 	}
 }
@@ -58,7 +159,7 @@ func StopTasksCmd(client *dms.Client, arns []string) tea.Cmd {
 func ResumeTasksCmd(client *dms.Client, arns []string) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
-		results := client.StartTasks(ctx, arns, types.StartReplicationTaskTypeValueResumeProcessing)
+		results := client.StartTasks(ctx, arns, types.StartReplicationTaskTypeValueResumeProcessing, dms.CDCOptions{})
 		return taskOperationCompleteMsg{results: results}
 	}
 }
@@ -73,6 +174,7 @@ func ReloadTasksCmd(client *dms.Client, arns []string) tea.Cmd {
 }
 
 type tableStatsLoadedMsg struct {
+	arn   string
 	stats []dms.TableStatistic
 	err   error
 }
@@ -82,15 +184,49 @@ func LoadTableStatsCmd(client *dms.Client, arn string) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
 		stats, err := client.GetTableStatistics(ctx, arn)
-		return tableStatsLoadedMsg{stats: stats, err: err}
+		return tableStatsLoadedMsg{arn: arn, stats: stats, err: err}
+	}
+}
+
+type tablesReloadedMsg struct {
+	arn   string
+	mode  dms.ReloadMode
+	count int
+	err   error
+}
+
+// ReloadTablesCmd reloads or revalidates tables of a task asynchronously
+func ReloadTablesCmd(client *dms.Client, arn string, tables []dms.TableRef, mode dms.ReloadMode) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		err := client.ReloadTables(ctx, arn, tables, mode)
+		return tablesReloadedMsg{arn: arn, mode: mode, count: len(tables), err: err}
+	}
+}
+
+type throughputSampledMsg struct {
+	at      time.Time
+	results []dms.TaskTableStatistics
+}
+
+// SampleThroughputCmd polls table statistics for the given tasks so that
+// throughput rates can be derived from successive samples
+func SampleThroughputCmd(client *dms.Client, arns []string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		results := client.GetTableStatisticsBatch(ctx, arns)
+		return throughputSampledMsg{at: time.Now(), results: results}
 	}
 }
 
-// TickCmd returns a command that waits and then sends a tick message
-func TickCmd() tea.Cmd {
-	return tea.Tick(refreshInterval, func(_ time.Time) tea.Msg {
-		return tickMsg{}
+// TickCmd returns a command that waits for d and then sends a tick message
+// tagged with gen
+func TickCmd(d time.Duration, gen int) tea.Cmd {
+	return tea.Tick(d, func(_ time.Time) tea.Msg {
+		return tickMsg{gen: gen}
 	})
 }
 
-type tickMsg struct{}
+type tickMsg struct {
+	gen int
+}
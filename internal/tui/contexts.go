@@ -0,0 +1,356 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/eljosho/dms-manager/pkg/dms"
+)
+
+// Context picker stages
+const (
+	pickProfile = iota
+	pickRegion
+)
+
+// contextPicker holds the state of the profile and region picker
+type contextPicker struct {
+	profiles  []string
+	stage     int
+	cursor    int
+	profile   string
+	switching bool
+	err       error
+}
+
+// regionOptions returns the choices for the region stage; the empty string
+// stands for the region configured in the chosen profile
+func (p contextPicker) regionOptions() []string {
+	return append([]string{""}, dms.Regions...)
+}
+
+// optionCount returns the number of choices in the current stage
+func (p contextPicker) optionCount() int {
+	if p.stage == pickRegion {
+		return len(p.regionOptions())
+	}
+	return len(p.profiles)
+}
+
+// contextState is the per-context view state restored when switching back
+// to a previously visited profile and region
+type contextState struct {
+	cursor       int
+	selected     map[string]bool
+	taskFilter   string
+	statsFilter  string
+	statsSort    tableStatsSort
+	statsSortAsc bool
+}
+
+// contextName identifies an AWS profile and region pair
+func contextName(profile, region string) string {
+	if profile == "" {
+		profile = "default"
+	}
+	return profile + "/" + region
+}
+
+// contextProfile returns the profile name of a client as listed in the picker
+func contextProfile(client *dms.Client) string {
+	if client.GetProfile() == "" {
+		return "default"
+	}
+	return client.GetProfile()
+}
+
+// clientContextName returns the context name of a client
+func clientContextName(client *dms.Client) string {
+	return contextName(client.GetProfile(), client.GetRegion())
+}
+
+// contextClients returns the clients whose tasks are shown, keyed by context
+// name: the active client plus, in the merged view, every pinned context
+func (m Model) contextClients() map[string]*dms.Client {
+	clients := map[string]*dms.Client{clientContextName(m.client): m.client}
+	if m.merged {
+		for name, c := range m.pinnedClients {
+			clients[name] = c
+		}
+	}
+	return clients
+}
+
+// clientFor returns the client owning a task, which differs from the active
+// client only for tasks of other contexts in the merged view
+func (m Model) clientFor(arn string) *dms.Client {
+	if m.merged {
+		if c, ok := m.pinnedClients[m.taskContexts[arn]]; ok {
+			return c
+		}
+	}
+	return m.client
+}
+
+// forEachClient groups task ARNs by owning client and batches the command
+// built for each group
+func (m Model) forEachClient(arns []string, build func(*dms.Client, []string) tea.Cmd) tea.Cmd {
+	groups := make(map[*dms.Client][]string)
+	var order []*dms.Client
+	for _, arn := range arns {
+		c := m.clientFor(arn)
+		if _, ok := groups[c]; !ok {
+			order = append(order, c)
+		}
+		groups[c] = append(groups[c], arn)
+	}
+
+	cmds := make([]tea.Cmd, 0, len(order))
+	for _, c := range order {
+		cmds = append(cmds, build(c, groups[c]))
+	}
+	return tea.Batch(cmds...)
+}
+
+// loadTasksCmd loads tasks from the active context, or from every context in
+// the merged view
+func (m Model) loadTasksCmd() tea.Cmd {
+	if m.merged {
+		return LoadMergedTasksCmd(m.client, m.contextClients())
+	}
+	return LoadTasksCmd(m.client)
+}
+
+// saveContextState remembers the view state of the active context
+func (m *Model) saveContextState() {
+	m.contextStates[clientContextName(m.client)] = contextState{
+		cursor:       m.cursor,
+		selected:     m.selected,
+		taskFilter:   m.taskFilter,
+		statsFilter:  m.statsFilter,
+		statsSort:    m.statsSort,
+		statsSortAsc: m.statsSortAsc,
+	}
+}
+
+// restoreContextState restores the view state of the active context, or
+// resets it for a context that has not been visited yet
+func (m *Model) restoreContextState() {
+	st, ok := m.contextStates[clientContextName(m.client)]
+	if !ok {
+		st = contextState{selected: make(map[string]bool)}
+	}
+	m.cursor = st.cursor
+	m.selected = st.selected
+	m.taskFilter = st.taskFilter
+	m.statsFilter = st.statsFilter
+	m.statsSort = st.statsSort
+	m.statsSortAsc = st.statsSortAsc
+}
+
+// openContextPicker shows the profile picker
+func (m Model) openContextPicker() (tea.Model, tea.Cmd) {
+	m.pickerReturn = m.state
+	m.picker = contextPicker{}
+	m.state = viewContextPicker
+	return m, LoadProfilesCmd()
+}
+
+func (m Model) handleContextPickerKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := &m.picker
+	if p.switching {
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+
+	case "down", "j":
+		if p.cursor < p.optionCount()-1 {
+			p.cursor++
+		}
+
+	case "esc", "backspace":
+		if p.stage == pickRegion {
+			p.stage = pickProfile
+			p.cursor = indexOf(p.profiles, p.profile)
+			return m, nil
+		}
+		m.state = m.pickerReturn
+
+	case "enter":
+		if p.stage == pickProfile {
+			if p.cursor >= len(p.profiles) {
+				return m, nil
+			}
+			p.profile = p.profiles[p.cursor]
+			p.stage = pickRegion
+			p.cursor = 0
+			return m, nil
+		}
+
+		// Switch the active context
+		p.switching = true
+		p.err = nil
+		return m, NewClientCmd(p.profile, p.regionOptions()[p.cursor], false)
+
+	case " ":
+		// Pin or unpin the context for the merged view
+		if p.stage != pickRegion {
+			return m, nil
+		}
+		region := p.regionOptions()[p.cursor]
+		if region != "" {
+			name := contextName(p.profile, region)
+			if _, ok := m.pinnedClients[name]; ok {
+				delete(m.pinnedClients, name)
+				if len(m.pinnedClients) == 0 {
+					m.merged = false
+				}
+				return m, nil
+			}
+		}
+		p.err = nil
+		return m, NewClientCmd(p.profile, region, true)
+	}
+
+	return m, nil
+}
+
+// handleContextClient installs a newly created client, either as the active
+// context or as a context pinned for the merged view
+func (m Model) handleContextClient(msg contextClientMsg) (tea.Model, tea.Cmd) {
+	m.picker.switching = false
+	if msg.err != nil {
+		m.picker.err = msg.err
+		return m, nil
+	}
+
+	name := clientContextName(msg.client)
+
+	if msg.pin {
+		if name != clientContextName(m.client) {
+			m.pinnedClients[name] = msg.client
+		}
+		return m, nil
+	}
+
+	m.saveContextState()
+	m.client = msg.client
+	delete(m.pinnedClients, name)
+	m.restoreContextState()
+
+	m.tasks = nil
+	m.allTasks = nil
+	m.tableStats = nil
+	m.tableStatsARN = ""
+	m.changedTables = nil
+	m.statsCursor = 0
+	m.statsSelected = nil
+	m.instances = nil
+	m.instancesLoaded = false
+	m.instancesErr = nil
+	m.instancesCursor = 0
+	m.endpoints = nil
+	m.operationMsg = fmt.Sprintf("Switched to %s", name)
+	m.scheduler.Reset()
+	m.state = viewLoading
+	return m, tea.Batch(m.loadTasksCmd(), LoadEndpointsCmd(m.client), LoadInstancesCmd(m.client))
+}
+
+func (m Model) renderContextPicker() string {
+	var sb strings.Builder
+	p := m.picker
+
+	title := "Switch Context - Profile"
+	options := p.profiles
+	if p.stage == pickRegion {
+		title = fmt.Sprintf("Switch Context - Region for %s", p.profile)
+		options = p.regionOptions()
+	}
+
+	sb.WriteString(titleStyle.Render(title))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("%s %s\n\n", labelStyle.Render("Active:"), valueStyle.Render(clientContextName(m.client))))
+
+	if len(options) == 0 {
+		sb.WriteString(warningTextStyle.Render("No profiles found in the AWS config or credentials files."))
+		sb.WriteString("\n")
+	}
+
+	// Keep the cursor on screen for the long region list
+	start, end := 0, len(options)
+	if visible := m.height - 10; visible > 0 && len(options) > visible {
+		start = max(0, min(p.cursor-visible/2, len(options)-visible))
+		end = start + visible
+	}
+
+	for i := start; i < end; i++ {
+		label := options[i]
+		if p.stage == pickRegion && label == "" {
+			label = "(region from profile)"
+		}
+
+		cursor := "  "
+		nameStyle := normalItemStyle
+		if i == p.cursor {
+			cursor = selectedCursorStyle.Render("→ ")
+			nameStyle = selectedItemStyle
+		}
+
+		pin := ""
+		if p.stage == pickRegion {
+			pin = mutedCheckboxStyle.Render("[ ] ")
+			if _, ok := m.pinnedClients[contextName(p.profile, options[i])]; ok {
+				pin = checkmarkStyle.Render("[✓] ")
+			}
+		}
+
+		sb.WriteString(fmt.Sprintf("%s%s%s\n", cursor, pin, nameStyle.Render(label)))
+	}
+
+	if p.switching {
+		sb.WriteString("\n")
+		sb.WriteString(infoStyle.Render(m.spinner.View() + " Connecting..."))
+		sb.WriteString("\n")
+	}
+	if p.err != nil {
+		sb.WriteString("\n")
+		sb.WriteString(errorStyle.Render(fmt.Sprintf("Failed to switch context: %v", p.err)))
+		sb.WriteString("\n")
+	}
+
+	if len(m.pinnedClients) > 0 {
+		names := make([]string, 0, len(m.pinnedClients))
+		for name := range m.pinnedClients {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Pinned for merged view:"), mutedTextStyle.Render(strings.Join(names, ", "))))
+	}
+
+	sb.WriteString("\n")
+	if p.stage == pickRegion {
+		sb.WriteString(helpStyle.Render("Press [enter] switch • [space] pin for merged view • [ESC] back"))
+	} else {
+		sb.WriteString(helpStyle.Render("Press [enter] choose profile • [ESC] cancel"))
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+func indexOf(values []string, v string) int {
+	for i, s := range values {
+		if s == v {
+			return i
+		}
+	}
+	return 0
+}
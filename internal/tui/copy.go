@@ -0,0 +1,191 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/eljosho/dms-manager/pkg/dms"
+)
+
+// copyItem is an entry of the copy menu
+type copyItem struct {
+	key   string
+	name  string // argument of the :copy palette command
+	label string
+	run   func(m Model, task dms.Task, label string) tea.Cmd
+}
+
+// copyItems lists what can be copied from the current task
+var copyItems = []copyItem{
+	{"a", "arn", "task ARN", copyField(func(t dms.Task) string { return t.ARN })},
+	{"n", "name", "task name", copyField(func(t dms.Task) string { return t.Name })},
+	{"s", "source", "source endpoint ARN", copyField(func(t dms.Task) string { return t.SourceEndpointARN })},
+	{"t", "target", "target endpoint ARN", copyField(func(t dms.Task) string { return t.TargetEndpointARN })},
+	{"i", "instance", "replication instance ARN", copyField(func(t dms.Task) string { return t.ReplicationInstanceARN })},
+	{"m", "summary", "status summary", func(m Model, t dms.Task, label string) tea.Cmd { return CopyCmd(m.taskSummary(t), label, false) }},
+	{"c", "console", "console link: task", consoleLink(dms.ResourceTask, func(t dms.Task) string { return t.ARN })},
+	{"I", "console-instance", "console link: instance", consoleLink(dms.ResourceInstance, func(t dms.Task) string { return t.ReplicationInstanceARN })},
+	{"S", "console-source", "console link: source endpoint", consoleLink(dms.ResourceEndpoint, func(t dms.Task) string { return t.SourceEndpointARN })},
+	{"T", "console-target", "console link: target endpoint", consoleLink(dms.ResourceEndpoint, func(t dms.Task) string { return t.TargetEndpointARN })},
+}
+
+func copyField(field func(dms.Task) string) func(Model, dms.Task, string) tea.Cmd {
+	return func(_ Model, t dms.Task, label string) tea.Cmd {
+		return CopyCmd(field(t), label, true)
+	}
+}
+
+func consoleLink(kind string, arn func(dms.Task) string) func(Model, dms.Task, string) tea.Cmd {
+	return func(m Model, t dms.Task, _ string) tea.Cmd {
+		return ConsoleLinkCmd(m.clientFor(t.ARN), kind, arn(t), t.Name)
+	}
+}
+
+// findCopyItem returns the copy menu entry for a key or :copy argument
+func findCopyItem(keyOrName string) (copyItem, bool) {
+	for _, item := range copyItems {
+		if item.key == keyOrName || item.name == keyOrName {
+			return item, true
+		}
+	}
+	return copyItem{}, false
+}
+
+type consoleLinkMsg struct {
+	url string
+	err error
+}
+
+// ConsoleLinkCmd builds the console link of a resource. Tasks are linked by
+// name; instances and endpoints need a lookup of their identifier, and fall
+// back to the list page if it fails.
+func ConsoleLinkCmd(client *dms.Client, kind, arn, taskName string) tea.Cmd {
+	return func() tea.Msg {
+		if kind == dms.ResourceTask {
+			return consoleLinkMsg{url: dms.ConsoleURL(client.GetRegion(), kind, taskName)}
+		}
+
+		id, err := client.ResourceIdentifier(context.Background(), kind, arn)
+		return consoleLinkMsg{url: dms.ConsoleURL(client.GetRegion(), kind, id), err: err}
+	}
+}
+
+// currentTask returns the task the copy actions apply to: the task shown in
+// the details views, or the cursor task in the list
+func (m Model) currentTask() (dms.Task, bool) {
+	switch m.state {
+	case viewTaskDetails, viewTableStats, viewMappings:
+		return m.detailsTask()
+	}
+	if m.cursor >= len(m.tasks) {
+		return dms.Task{}, false
+	}
+	return m.tasks[m.cursor], true
+}
+
+// taskSummary formats a task's status for pasting into chat
+func (m Model) taskSummary(t dms.Task) string {
+	var sb strings.Builder
+
+	ctx := m.taskContexts[t.ARN]
+	if ctx == "" {
+		ctx = clientContextName(m.client)
+	}
+	sb.WriteString(fmt.Sprintf("%s (%s): %s, %s", t.Name, ctx, t.Status, t.MigrationType))
+
+	if s := t.ReplicationTaskStats; s != nil {
+		sb.WriteString(fmt.Sprintf(", %d%% loaded, tables %d loaded / %d loading / %d queued / %d errored",
+			s.FullLoadProgressPercent, s.TablesLoaded, s.TablesLoading, s.TablesQueued, s.TablesErrored))
+	}
+	if t.StopReason != "" {
+		sb.WriteString(", stop reason: " + t.StopReason)
+	}
+	if t.LastFailureMessage != "" {
+		sb.WriteString("\nLast failure: " + t.LastFailureMessage)
+	}
+	sb.WriteString("\n" + t.ARN)
+
+	return sb.String()
+}
+
+// openCopyMenu shows the copy menu for the current task
+func (m Model) openCopyMenu() (tea.Model, tea.Cmd) {
+	if _, ok := m.currentTask(); !ok {
+		return m, nil
+	}
+	m.copyMenu = true
+	return m, nil
+}
+
+func (m Model) handleCopyMenuKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if key == "ctrl+c" {
+		return m, tea.Quit
+	}
+
+	m.copyMenu = false
+	item, ok := findCopyItem(key)
+	if !ok {
+		return m, nil
+	}
+	return m.runCopyItem(item)
+}
+
+func (m Model) runCopyItem(item copyItem) (tea.Model, tea.Cmd) {
+	task, ok := m.currentTask()
+	if !ok {
+		return m, nil
+	}
+	if strings.HasPrefix(item.name, "console") {
+		m.operationMsg = "Building console link..."
+	}
+
+	return m, item.run(m, task, item.label)
+}
+
+// renderCopyMenu renders the copy menu below the current view
+func (m Model) renderCopyMenu() string {
+	task, _ := m.currentTask()
+
+	var sb strings.Builder
+	sb.WriteString(sectionHeaderStyle.UnsetMarginTop().Render("Copy from " + task.Name))
+	sb.WriteString("\n")
+	for _, item := range copyItems {
+		sb.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render(fmt.Sprintf("[%s]", item.key)), valueStyle.Render(item.label)))
+	}
+	sb.WriteString(helpStyle.Render("Press a key to copy • any other key cancels"))
+
+	return paletteStyle.Render(sb.String())
+}
+
+func runCopyCommand(m Model, args []string) (tea.Model, tea.Cmd) {
+	name := "arn"
+	if len(args) > 0 {
+		name = args[0]
+	}
+	item, ok := findCopyItem(name)
+	if !ok || len(name) == 1 {
+		names := make([]string, len(copyItems))
+		for i, item := range copyItems {
+			names[i] = item.name
+		}
+		m.operationMsg = "Usage: :copy " + strings.Join(names, "|")
+		return m, nil
+	}
+	return m.runCopyItem(item)
+}
+
+func runConsoleCommand(m Model, args []string) (tea.Model, tea.Cmd) {
+	name := "console"
+	if len(args) > 0 && args[0] != "task" {
+		name = "console-" + args[0]
+	}
+	item, ok := findCopyItem(name)
+	if !ok {
+		m.operationMsg = "Usage: :console [task|instance|source|target]"
+		return m, nil
+	}
+	return m.runCopyItem(item)
+}
@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/eljosho/dms-manager/pkg/dms"
+)
+
+type exportCompleteMsg struct {
+	path  string
+	count int
+	err   error
+}
+
+// exportedTask is the flattened form of a task written by :export
+type exportedTask struct {
+	Context       string `json:"context,omitempty"`
+	Name          string `json:"name"`
+	Status        string `json:"status"`
+	MigrationType string `json:"migrationType"`
+	ARN           string `json:"arn"`
+	Progress      int32  `json:"fullLoadProgressPercent"`
+	TablesLoaded  int32  `json:"tablesLoaded"`
+	TablesErrored int32  `json:"tablesErrored"`
+	LastFailure   string `json:"lastFailureMessage,omitempty"`
+}
+
+// ExportTasksCmd writes tasks to a CSV or JSON file. When path is empty a
+// timestamped file is created in the current directory.
+func ExportTasksCmd(tasks []dms.Task, contexts map[string]string, format, path string) tea.Cmd {
+	return func() tea.Msg {
+		if path == "" {
+			path = fmt.Sprintf("dms-tasks-%s.%s", time.Now().Format("20060102-150405"), format)
+		}
+
+		rows := make([]exportedTask, 0, len(tasks))
+		for _, t := range tasks {
+			row := exportedTask{
+				Context:       contexts[t.ARN],
+				Name:          t.Name,
+				Status:        t.Status,
+				MigrationType: t.MigrationType,
+				ARN:           t.ARN,
+				LastFailure:   t.LastFailureMessage,
+			}
+			if t.ReplicationTaskStats != nil {
+				row.Progress = t.ReplicationTaskStats.FullLoadProgressPercent
+				row.TablesLoaded = t.ReplicationTaskStats.TablesLoaded
+				row.TablesErrored = t.ReplicationTaskStats.TablesErrored
+			}
+			rows = append(rows, row)
+		}
+
+		f, err := os.Create(path)
+		if err != nil {
+			return exportCompleteMsg{err: fmt.Errorf("failed to create export file: %w", err)}
+		}
+		defer f.Close()
+
+		if format == "json" {
+			enc := json.NewEncoder(f)
+			enc.SetIndent("", "  ")
+			err = enc.Encode(rows)
+		} else {
+			err = writeTasksCSV(f, rows)
+		}
+		if err != nil {
+			return exportCompleteMsg{err: fmt.Errorf("failed to write export file: %w", err)}
+		}
+
+		return exportCompleteMsg{path: path, count: len(rows)}
+	}
+}
+
+func writeTasksCSV(f *os.File, rows []exportedTask) error {
+	w := csv.NewWriter(f)
+	w.Write([]string{"context", "name", "status", "migration_type", "arn", "full_load_progress_percent", "tables_loaded", "tables_errored", "last_failure_message"})
+	for _, r := range rows {
+		w.Write([]string{
+			r.Context,
+			r.Name,
+			r.Status,
+			r.MigrationType,
+			r.ARN,
+			strconv.Itoa(int(r.Progress)),
+			strconv.Itoa(int(r.TablesLoaded)),
+			strconv.Itoa(int(r.TablesErrored)),
+			r.LastFailure,
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
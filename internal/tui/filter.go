@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/eljosho/dms-manager/pkg/dms"
+)
+
+// parseFilterTerms splits a filter expression into space separated terms.
+// Terms of the form key=value with a known key are grouped under that key;
+// every other term is grouped under the empty key. Terms are lowercased so
+// that matching with containsAll is case-insensitive.
+func parseFilterTerms(expr string, keys ...string) map[string][]string {
+	terms := make(map[string][]string)
+	for _, term := range strings.Fields(strings.ToLower(expr)) {
+		key, value, found := strings.Cut(term, "=")
+		if found && containsString(keys, key) {
+			terms[key] = append(terms[key], value)
+			continue
+		}
+		terms[""] = append(terms[""], term)
+	}
+	return terms
+}
+
+// containsAll reports whether value contains every term, ignoring case
+func containsAll(value string, terms []string) bool {
+	value = strings.ToLower(value)
+	for _, t := range terms {
+		if !strings.Contains(value, t) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsString(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// taskFilter matches tasks against a filter expression. "status", "name",
+// "type" and "context" terms match the respective field, any other term
+// matches the task name.
+type taskFilter map[string][]string
+
+func parseTaskFilter(expr string) taskFilter {
+	return taskFilter(parseFilterTerms(expr, "status", "name", "type", "context"))
+}
+
+func (f taskFilter) matches(t dms.Task, context string) bool {
+	return containsAll(t.Status, f["status"]) &&
+		containsAll(t.Name, f["name"]) &&
+		containsAll(t.MigrationType, f["type"]) &&
+		containsAll(context, f["context"]) &&
+		containsAll(t.Name, f[""])
+}
+
+// applyTaskFilter rebuilds the visible task list from all loaded tasks.
+// Selected tasks that are no longer listed are deselected, so task
+// operations never act on tasks the user can't see.
+func (m *Model) applyTaskFilter() {
+	if m.taskFilter == "" {
+		m.tasks = m.allTasks
+	} else {
+		filter := parseTaskFilter(m.taskFilter)
+		m.tasks = make([]dms.Task, 0, len(m.allTasks))
+		for _, t := range m.allTasks {
+			if filter.matches(t, m.taskContexts[t.ARN]) {
+				m.tasks = append(m.tasks, t)
+			}
+		}
+	}
+
+	listed := make(map[string]bool, len(m.tasks))
+	for _, t := range m.tasks {
+		listed[t.ARN] = true
+	}
+	for arn := range m.selected {
+		if !listed[arn] {
+			delete(m.selected, arn)
+		}
+	}
+
+	if m.cursor >= len(m.tasks) {
+		m.cursor = max(len(m.tasks)-1, 0)
+	}
+}
@@ -0,0 +1,244 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eljosho/dms-manager/pkg/dms"
+)
+
+type instancesLoadedMsg struct {
+	client    *dms.Client
+	instances []dms.ReplicationInstance
+	err       error
+}
+
+// LoadInstancesCmd loads the replication instances asynchronously
+func LoadInstancesCmd(client *dms.Client) tea.Cmd {
+	return func() tea.Msg {
+		instances, err := client.ListReplicationInstances(context.Background())
+		return instancesLoadedMsg{client: client, instances: instances, err: err}
+	}
+}
+
+// handleInstancesLoaded stores a refreshed instance list. Failed background
+// refreshes keep the last good list on screen.
+func (m Model) handleInstancesLoaded(msg instancesLoadedMsg) (tea.Model, tea.Cmd) {
+	// Ignore results for a context that is no longer active
+	if msg.client != m.client {
+		return m, nil
+	}
+	m.health.record(msg.err, time.Now())
+	m.instancesErr = msg.err
+	if msg.err != nil {
+		return m, nil
+	}
+	m.instances = msg.instances
+	m.instancesLoaded = true
+	m.instancesCursor = min(m.instancesCursor, max(len(m.instances)-1, 0))
+	return m, nil
+}
+
+// openInstances switches to the instances tab, loading the instances of the
+// active context
+func (m Model) openInstances() (tea.Model, tea.Cmd) {
+	m.state = viewInstances
+	return m, LoadInstancesCmd(m.client)
+}
+
+// renderTabs renders the tab bar shared by the task list and instances views
+func (m Model) renderTabs() string {
+	tabs := []struct {
+		label string
+		state viewState
+	}{
+		{"Tasks", viewTaskList},
+		{"Instances", viewInstances},
+	}
+
+	parts := make([]string, len(tabs))
+	for i, t := range tabs {
+		if t.state == m.state {
+			parts[i] = activeTabStyle.Render(t.label)
+		} else {
+			parts[i] = inactiveTabStyle.Render(t.label)
+		}
+	}
+	key := keyLabelFor(m.state, actionSwitchTab)
+	return strings.Join(parts, " ") + " " + mutedTextStyle.Render(key) + "\n"
+}
+
+// renderInstances renders the replication instances of the active context
+// with the tasks hosted on each
+func (m Model) renderInstances() string {
+	var sb strings.Builder
+
+	sb.WriteString(m.renderTabs())
+	title := fmt.Sprintf("AWS DMS Replication Instances - %s", m.client.GetRegion())
+	if m.client.GetProfile() != "" {
+		title += fmt.Sprintf(" (Profile: %s)", m.client.GetProfile())
+	}
+	sb.WriteString(titleStyle.Render(title))
+	sb.WriteString("\n")
+
+	if m.operationMsg != "" {
+		sb.WriteString(infoStyle.Render(m.operationMsg))
+		sb.WriteString("\n\n")
+	}
+
+	switch {
+	case !m.instancesLoaded && m.instancesErr != nil:
+		sb.WriteString(errorTextStyle.Render(fmt.Sprintf("Failed to load instances: %v", m.instancesErr)))
+		sb.WriteString("\n")
+	case !m.instancesLoaded:
+		sb.WriteString(fmt.Sprintf("%s Loading replication instances...\n", m.spinner.View()))
+	case len(m.instances) == 0:
+		sb.WriteString(warningTextStyle.Render("No replication instances found."))
+		sb.WriteString("\n")
+	default:
+		sb.WriteString(m.renderInstanceRows())
+		sb.WriteString(m.renderInstanceDetails(m.instances[m.instancesCursor]))
+	}
+
+	if m.instancesLoaded && m.instancesErr != nil {
+		sb.WriteString("\n")
+		sb.WriteString(errorTextStyle.Render(truncateString(fmt.Sprintf("Refresh failed: %v", m.instancesErr), m.screenWidth())))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(m.renderBindingsHelp(viewInstances))
+	sb.WriteString(m.renderStatusBar())
+
+	return sb.String()
+}
+
+// renderInstanceRows renders the instances table with a cursor on the
+// instance whose details are shown below it
+func (m Model) renderInstanceRows() string {
+	hosted := dms.TasksByInstance(m.allTasks)
+
+	headers := []string{"IDENTIFIER", "CLASS", "ENGINE", "STORAGE", "AZ", "STATUS", "TASKS", "PENDING"}
+	rows := make([][]string, len(m.instances))
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = len(h)
+	}
+	for i, inst := range m.instances {
+		pending := "-"
+		if len(inst.PendingModifications) > 0 {
+			pending = strings.Join(inst.PendingModifications, ", ")
+		}
+		az := inst.AvailabilityZone
+		if inst.MultiAZ && inst.SecondaryAvailabilityZone != "" {
+			az += " / " + inst.SecondaryAvailabilityZone
+		}
+		rows[i] = []string{
+			inst.Identifier,
+			inst.Class,
+			inst.EngineVersion,
+			fmt.Sprintf("%d GB", inst.AllocatedStorage),
+			az,
+			inst.Status,
+			fmt.Sprintf("%d", len(hosted[inst.ARN])),
+			pending,
+		}
+		// The last column is left unpadded
+		for j, cell := range rows[i][:len(headers)-1] {
+			widths[j] = max(widths[j], lipgloss.Width(cell))
+		}
+	}
+
+	pad := func(s string, col int) string {
+		if col == len(headers)-1 {
+			return s
+		}
+		return s + strings.Repeat(" ", widths[col]-lipgloss.Width(s)+2)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("  ")
+	for i, h := range headers {
+		sb.WriteString(tableHeaderStyle.Render(pad(h, i)))
+	}
+	sb.WriteString("\n")
+
+	for i, row := range rows {
+		cursor := "  "
+		nameStyle := normalItemStyle
+		if i == m.instancesCursor {
+			cursor = selectedCursorStyle.Render("→ ")
+			nameStyle = selectedItemStyle
+		}
+
+		sb.WriteString(cursor)
+		for j, cell := range row {
+			style := valueStyle
+			switch j {
+			case 0:
+				style = nameStyle
+			case 3, 6:
+				style = numberStyle
+			case 5:
+				style = instanceStatusStyle(cell)
+			case 7:
+				if cell != "-" {
+					style = warningTextStyle
+				} else {
+					style = mutedTextStyle
+				}
+			}
+			sb.WriteString(style.Render(pad(cell, j)))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// renderInstanceDetails renders the maintenance settings, pending
+// modifications and hosted tasks of an instance
+func (m Model) renderInstanceDetails(inst dms.ReplicationInstance) string {
+	var sb strings.Builder
+
+	sb.WriteString(sectionHeaderStyle.Render(inst.Identifier))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("ARN:"), arnStyle.Render(inst.ARN)))
+	sb.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Multi-AZ:"), valueStyle.Render(fmt.Sprintf("%t", inst.MultiAZ))))
+	if inst.MaintenanceWindow != "" {
+		sb.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Maintenance Window:"), valueStyle.Render(inst.MaintenanceWindow)))
+	}
+	if len(inst.PendingModifications) > 0 {
+		sb.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Pending Modifications:"),
+			warningTextStyle.Render(strings.Join(inst.PendingModifications, ", "))))
+	}
+
+	tasks := dms.TasksByInstance(m.allTasks)[inst.ARN]
+	sb.WriteString(fmt.Sprintf("%s\n", labelStyle.Render(fmt.Sprintf("Hosted Tasks (%d):", len(tasks)))))
+	if len(tasks) == 0 {
+		sb.WriteString("  " + mutedTextStyle.Render("none") + "\n")
+	}
+	for _, task := range tasks {
+		status := GetStatusStyle(strings.ToLower(task.Status)).Render(task.Status)
+		sb.WriteString(fmt.Sprintf("  %s - %s %s\n", normalItemStyle.Render(task.Name), status,
+			mutedTextStyle.Render(fmt.Sprintf("(%s)", task.MigrationType))))
+	}
+
+	return sb.String()
+}
+
+// instanceStatusStyle returns the style for a replication instance status
+func instanceStatusStyle(status string) lipglossStyle {
+	switch strings.ToLower(status) {
+	case "available":
+		return statusRunningStyle
+	case "failed", "storage-full", "incompatible-network", "inaccessible-encryption-credentials":
+		return statusStoppedStyle
+	default:
+		return statusOtherStyle
+	}
+}
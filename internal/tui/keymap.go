@@ -0,0 +1,381 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// action names something the user can do from the keyboard
+type action string
+
+const (
+	// Global actions
+	actionQuit        action = "quit"
+	actionRefresh     action = "refresh"
+	actionAutoRefresh action = "auto-refresh"
+	actionPalette     action = "palette"
+	actionHelp        action = "help"
+
+	// Error view actions
+	actionRetry action = "retry"
+
+	// Task list actions
+	actionUp         action = "up"
+	actionDown       action = "down"
+	actionSelect     action = "select"
+	actionDetails    action = "details"
+	actionTableStats action = "table-stats"
+	actionStart      action = "start"
+	actionStop       action = "stop"
+	actionResume     action = "resume"
+	actionReload     action = "reload"
+	actionClear      action = "clear"
+	actionContext    action = "context"
+	actionMerged     action = "merged"
+
+	actionTransitions action = "transitions"
+	actionCopyMenu    action = "copy-menu"
+	actionCreate      action = "create"
+	actionSwitchTab   action = "switch-tab"
+
+	// Task details actions
+	actionBack     action = "back"
+	actionMappings action = "mappings"
+
+	// Table statistics actions
+	actionSort        action = "sort"
+	actionReverseSort action = "reverse-sort"
+	actionFilter      action = "filter"
+	actionScrollLeft  action = "scroll-left"
+	actionScrollRight action = "scroll-right"
+	actionScrollHome  action = "scroll-home"
+
+	actionReloadTables   action = "reload-tables"
+	actionValidateTables action = "validate-tables"
+
+	// Scrolling actions of the details, table statistics and mappings views
+	actionLineUp   action = "line-up"
+	actionLineDown action = "line-down"
+	actionPageUp   action = "page-up"
+	actionPageDown action = "page-down"
+	actionTop      action = "top"
+	actionBottom   action = "bottom"
+
+	// Table mappings actions
+	actionToggleJSON action = "toggle-json"
+	actionCopy       action = "copy"
+)
+
+// binding maps keys to an action. status, when set, renders the current
+// state of a toggle next to the help text.
+type binding struct {
+	action action
+	keys   []string
+	help   string
+	status func(m Model) string
+}
+
+// globalBindings apply in every view that isn't capturing text input
+var globalBindings = []binding{
+	{action: actionQuit, keys: []string{"q", "ctrl+c"}, help: "quit"},
+	{action: actionRefresh, keys: []string{"f"}, help: "refresh"},
+	{action: actionAutoRefresh, keys: []string{"a"}, help: "auto-refresh", status: func(m Model) string { return onOff(m.autoRefresh) }},
+	{action: actionPalette, keys: []string{":"}, help: "command"},
+	{action: actionHelp, keys: []string{"?"}, help: "help"},
+}
+
+// viewBindings holds the bindings of each view, in the order they are listed
+// in the help
+var viewBindings = map[viewState][]binding{
+	viewTaskList: {
+		{action: actionUp, keys: []string{"up", "k"}, help: "up"},
+		{action: actionDown, keys: []string{"down", "j"}, help: "down"},
+		{action: actionSelect, keys: []string{" "}, help: "select"},
+		{action: actionDetails, keys: []string{"enter"}, help: "details"},
+		{action: actionTableStats, keys: []string{"T"}, help: "table stats"},
+		{action: actionStart, keys: []string{"s"}, help: "start"},
+		{action: actionStop, keys: []string{"x"}, help: "stop"},
+		{action: actionResume, keys: []string{"r"}, help: "resume"},
+		{action: actionReload, keys: []string{"l"}, help: "reload"},
+		{action: actionClear, keys: []string{"c"}, help: "clear"},
+		{action: actionContext, keys: []string{"P"}, help: "context"},
+		{action: actionMerged, keys: []string{"M"}, help: "merged", status: func(m Model) string { return onOff(m.merged) }},
+		{action: actionTransitions, keys: []string{"L"}, help: "transitions"},
+		{action: actionCopyMenu, keys: []string{"y"}, help: "copy"},
+		{action: actionCreate, keys: []string{"n"}, help: "new task"},
+		{action: actionSwitchTab, keys: []string{"tab"}, help: "instances"},
+	},
+	viewInstances: {
+		{action: actionUp, keys: []string{"up", "k"}, help: "up"},
+		{action: actionDown, keys: []string{"down", "j"}, help: "down"},
+		{action: actionSwitchTab, keys: []string{"tab"}, help: "tasks"},
+		{action: actionBack, keys: []string{"esc", "backspace"}, help: "back"},
+	},
+	viewError: {
+		{action: actionRetry, keys: []string{"r"}, help: "retry"},
+	},
+	viewTaskDetails: {
+		{action: actionMappings, keys: []string{"t"}, help: "table mappings"},
+		{action: actionTableStats, keys: []string{"T"}, help: "table stats"},
+		{action: actionCopyMenu, keys: []string{"y"}, help: "copy"},
+		{action: actionLineUp, keys: []string{"up", "k"}, help: "up"},
+		{action: actionLineDown, keys: []string{"down", "j"}, help: "down"},
+		{action: actionPageUp, keys: []string{"pgup", "ctrl+u"}, help: "page up"},
+		{action: actionPageDown, keys: []string{"pgdown", "ctrl+d"}, help: "page down"},
+		{action: actionTop, keys: []string{"home", "g"}, help: "top"},
+		{action: actionBottom, keys: []string{"end", "G"}, help: "bottom"},
+		{action: actionBack, keys: []string{"esc", "backspace"}, help: "back"},
+	},
+	viewTableStats: {
+		{action: actionSort, keys: []string{"o"}, help: "sort"},
+		{action: actionReverseSort, keys: []string{"O"}, help: "reverse"},
+		{action: actionFilter, keys: []string{"/"}, help: "filter"},
+		{action: actionScrollLeft, keys: []string{"left", "h"}, help: "scroll left"},
+		{action: actionScrollRight, keys: []string{"right", "l"}, help: "scroll right"},
+		{action: actionScrollHome, keys: []string{"home", "0"}, help: "scroll start"},
+		{action: actionSelect, keys: []string{" "}, help: "select"},
+		{action: actionClear, keys: []string{"c"}, help: "clear"},
+		{action: actionReloadTables, keys: []string{"R"}, help: "reload tables"},
+		{action: actionValidateTables, keys: []string{"V"}, help: "revalidate tables"},
+		{action: actionLineUp, keys: []string{"up", "k"}, help: "up"},
+		{action: actionLineDown, keys: []string{"down", "j"}, help: "down"},
+		{action: actionPageUp, keys: []string{"pgup", "ctrl+u"}, help: "page up"},
+		{action: actionPageDown, keys: []string{"pgdown", "ctrl+d"}, help: "page down"},
+		{action: actionTop, keys: []string{"g"}, help: "top"},
+		{action: actionBottom, keys: []string{"end", "G"}, help: "bottom"},
+		{action: actionBack, keys: []string{"esc", "backspace", "q"}, help: "back"},
+	},
+	viewTransitions: {
+		{action: actionBack, keys: []string{"esc", "backspace", "q"}, help: "back"},
+	},
+	viewMappings: {
+		{action: actionLineUp, keys: []string{"up", "k"}, help: "up"},
+		{action: actionLineDown, keys: []string{"down", "j"}, help: "down"},
+		{action: actionPageUp, keys: []string{"pgup", "ctrl+u"}, help: "page up"},
+		{action: actionPageDown, keys: []string{"pgdown", "ctrl+d"}, help: "page down"},
+		{action: actionTop, keys: []string{"home", "g"}, help: "top"},
+		{action: actionBottom, keys: []string{"end", "G"}, help: "bottom"},
+		{action: actionToggleJSON, keys: []string{"v"}, help: "JSON", status: func(m Model) string { return onOff(m.mappingsJSON) }},
+		{action: actionCopy, keys: []string{"y"}, help: "copy JSON"},
+		{action: actionBack, keys: []string{"esc", "backspace", "q"}, help: "back"},
+	},
+}
+
+// viewTitles names the views in the help overlay
+var viewTitles = map[viewState]string{
+	viewError:       "Error",
+	viewTaskList:    "Task List",
+	viewTaskDetails: "Task Details",
+	viewTableStats:  "Table Statistics",
+	viewTransitions: "Transitions",
+	viewMappings:    "Table Mappings",
+	viewInstances:   "Replication Instances",
+}
+
+// lookupAction resolves a key press to an action for the given view. View
+// bindings take precedence over global ones.
+func lookupAction(state viewState, key string) (action, bool) {
+	for _, b := range viewBindings[state] {
+		for _, k := range b.keys {
+			if k == key {
+				return b.action, true
+			}
+		}
+	}
+	for _, b := range globalBindings {
+		for _, k := range b.keys {
+			if k == key {
+				return b.action, true
+			}
+		}
+	}
+	return "", false
+}
+
+// applyKeymap replaces the keys bound to actions, keyed by action name. An
+// action bound in several views gets the new keys in all of them.
+func applyKeymap(keys map[string][]string) error {
+	known := make(map[action]bool)
+	for _, b := range globalBindings {
+		known[b.action] = true
+	}
+	for _, bindings := range viewBindings {
+		for _, b := range bindings {
+			known[b.action] = true
+		}
+	}
+
+	for name, ks := range keys {
+		act := action(name)
+		if !known[act] {
+			return fmt.Errorf("unknown action %q in key bindings", name)
+		}
+		if len(ks) == 0 {
+			return fmt.Errorf("no keys given for action %q", name)
+		}
+		normalized := make([]string, len(ks))
+		for i, k := range ks {
+			normalized[i] = normalizeKey(k)
+		}
+		rebind(globalBindings, act, normalized)
+		for _, bindings := range viewBindings {
+			rebind(bindings, act, normalized)
+		}
+	}
+
+	if err := checkKeyConflicts("global", globalBindings); err != nil {
+		return err
+	}
+	for state, bindings := range viewBindings {
+		if err := checkKeyConflicts(viewTitles[state], bindings); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func rebind(bindings []binding, act action, keys []string) {
+	for i := range bindings {
+		if bindings[i].action == act {
+			bindings[i].keys = keys
+		}
+	}
+}
+
+// normalizeKey maps the key names accepted in the config file to the names
+// reported by Bubble Tea
+func normalizeKey(k string) string {
+	switch strings.ToLower(k) {
+	case "space":
+		return " "
+	case "escape":
+		return "esc"
+	case "return":
+		return "enter"
+	default:
+		return k
+	}
+}
+
+// checkKeyConflicts reports a key bound to two actions in the same scope
+func checkKeyConflicts(scope string, bindings []binding) error {
+	seen := make(map[string]action)
+	for _, b := range bindings {
+		for _, k := range b.keys {
+			if other, ok := seen[k]; ok && other != b.action {
+				return fmt.Errorf("key %q is bound to both %q and %q in %s bindings", k, other, b.action, scope)
+			}
+			seen[k] = b.action
+		}
+	}
+	return nil
+}
+
+// keyLabel renders a binding's keys the way they appear in the help
+func (b binding) keyLabel() string {
+	labels := make([]string, len(b.keys))
+	for i, k := range b.keys {
+		switch k {
+		case "up":
+			labels[i] = "↑"
+		case "down":
+			labels[i] = "↓"
+		case "left":
+			labels[i] = "←"
+		case "right":
+			labels[i] = "→"
+		case " ":
+			labels[i] = "space"
+		case "esc":
+			labels[i] = "ESC"
+		default:
+			labels[i] = k
+		}
+	}
+	return "[" + strings.Join(labels, "/") + "]"
+}
+
+// keyLabelFor returns the key label of an action in the given view, falling
+// back to the global bindings
+func keyLabelFor(state viewState, act action) string {
+	for _, b := range append(viewBindings[state], globalBindings...) {
+		if b.action == act {
+			return b.keyLabel()
+		}
+	}
+	return ""
+}
+
+// helpText renders a binding as "[keys] help", with toggle state if any
+func (b binding) helpText(m Model) string {
+	text := fmt.Sprintf("%s %s", b.keyLabel(), b.help)
+	if b.status != nil {
+		text += ": " + b.status(m)
+	}
+	return text
+}
+
+// renderBindingsHelp renders the bindings of a view followed by the global
+// ones, wrapped to the terminal width
+func (m Model) renderBindingsHelp(state viewState) string {
+	var items []string
+	shadowed := make(map[string]bool)
+	for _, b := range viewBindings[state] {
+		items = append(items, b.helpText(m))
+		for _, k := range b.keys {
+			shadowed[k] = true
+		}
+	}
+	// Leave out global keys that the view binds to something else
+	for _, b := range globalBindings {
+		var keys []string
+		for _, k := range b.keys {
+			if !shadowed[k] {
+				keys = append(keys, k)
+			}
+		}
+		if len(keys) == 0 {
+			continue
+		}
+		b.keys = keys
+		items = append(items, b.helpText(m))
+	}
+	return helpStyle.Render(m.wrapHelpItems(items))
+}
+
+// wrapHelpItems joins help items with bullets, breaking lines before they
+// exceed the terminal width
+func (m Model) wrapHelpItems(items []string) string {
+	width := m.width
+	if width == 0 {
+		width = 120
+	}
+
+	var lines []string
+	line := ""
+	for _, item := range items {
+		candidate := item
+		if line != "" {
+			candidate = line + " • " + item
+		}
+		if line != "" && lipgloss.Width(candidate) > width {
+			lines = append(lines, line)
+			line = item
+			continue
+		}
+		line = candidate
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func onOff(on bool) string {
+	if on {
+		return statusRunningStyle.Render("on")
+	}
+	return mutedTextStyle.Render("off")
+}
@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// splitMinWidth is the narrowest terminal that gets the split layout;
+	// anything smaller falls back to the full-screen list and details views
+	splitMinWidth = 120
+
+	// paneStatsRows caps the number of tables shown in the details pane
+	paneStatsRows = 8
+)
+
+// isSplitView reports whether the task list should be drawn side by side
+// with the details of the cursor task
+func (m Model) isSplitView() bool {
+	return m.width >= splitMinWidth
+}
+
+// renderSplitView renders the task list on the left and live details for the
+// cursor task on the right
+func (m Model) renderSplitView() string {
+	// Pane widths include padding but not the 2-column border; leave one
+	// spare column so the terminal never wraps the right edge
+	leftWidth := m.width*45/100 - 2
+	rightWidth := m.width - leftWidth - 5
+
+	left := paneStyle.Width(leftWidth).Render(m.renderTaskRows())
+
+	var right string
+	if m.cursor < len(m.tasks) {
+		task := m.tasks[m.cursor]
+		right = sectionHeaderStyle.Render(task.Name) + "\n" +
+			m.renderTaskDetailsBody(task, rightWidth-2) +
+			m.renderPaneTableStats(rightWidth)
+	} else {
+		right = mutedTextStyle.Render("No task selected.")
+	}
+	right = paneStyle.Width(rightWidth).Render(right)
+
+	var sb strings.Builder
+	sb.WriteString(m.renderTaskListHeader())
+	sb.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, left, right))
+	sb.WriteString("\n")
+	sb.WriteString(m.renderTransitionPane())
+	sb.WriteString(m.renderRefreshStatus())
+	sb.WriteString(m.renderHelp())
+	sb.WriteString(m.renderStatusBar())
+
+	return sb.String()
+}
+
+// renderPaneTableStats renders a compact table statistics summary for the
+// cursor task, refreshed alongside the task list
+func (m Model) renderPaneTableStats(width int) string {
+	var sb strings.Builder
+
+	sb.WriteString("\n")
+	sb.WriteString(sectionHeaderStyle.Render("Tables:"))
+	sb.WriteString("\n")
+
+	if m.cursor >= len(m.tasks) || m.tableStatsARN != m.tasks[m.cursor].ARN || m.tableStats == nil {
+		sb.WriteString(mutedTextStyle.Render("  loading..."))
+		sb.WriteString("\n")
+		return sb.String()
+	}
+
+	if len(m.tableStats) == 0 {
+		sb.WriteString(mutedTextStyle.Render("  No table statistics available."))
+		sb.WriteString("\n")
+		return sb.String()
+	}
+
+	// Leave room for padding, indent and the ROWS/CHANGES/STATE columns
+	nameWidth := width - 36
+	if nameWidth < 12 {
+		nameWidth = 12
+	}
+
+	header := fmt.Sprintf("  %-*s %9s %9s %-10s", nameWidth, "TABLE", "ROWS", "CHANGES", "STATE")
+	sb.WriteString(tableHeaderStyle.Render(header))
+	sb.WriteString("\n")
+
+	for i, s := range m.tableStats {
+		if i == paneStatsRows {
+			sb.WriteString(mutedTextStyle.Render(fmt.Sprintf("  ... %d more ([T] for all)", len(m.tableStats)-paneStatsRows)))
+			sb.WriteString("\n")
+			break
+		}
+
+		name := truncateString(s.SchemaName+"."+s.TableName, nameWidth)
+		changes := s.Inserts + s.Updates + s.Deletes
+		sb.WriteString(fmt.Sprintf("  %s %s %s %s\n",
+			valueStyle.Render(fmt.Sprintf("%-*s", nameWidth, name)),
+			numberStyle.Render(fmt.Sprintf("%9d", s.FullLoadRows)),
+			numberStyle.Render(fmt.Sprintf("%9d", changes)),
+			getTableValidationStyle(s.ValidationState).Render(truncateString(s.ValidationState, 10)),
+		))
+	}
+
+	return sb.String()
+}
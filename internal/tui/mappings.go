@@ -0,0 +1,230 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/eljosho/dms-manager/pkg/dms"
+)
+
+// mappingRuleGroups lists the rule types in display order with their titles
+var mappingRuleGroups = []struct {
+	ruleType string
+	title    string
+}{
+	{dms.RuleTypeSelection, "Selection rules"},
+	{dms.RuleTypeTransformation, "Transformation rules"},
+	{dms.RuleTypeTableSettings, "Table settings rules"},
+}
+
+// mappingsTask returns the task whose table mappings are shown
+func (m Model) mappingsTask() (dms.Task, bool) {
+	return m.detailsTask()
+}
+
+// mappingsSummary counts the rules of a task by type for the details view
+func (m Model) mappingsSummary(task dms.Task) string {
+	tm, err := dms.ParseTableMappings(task.TableMappings)
+	if err != nil {
+		return errorTextStyle.Render("invalid JSON")
+	}
+
+	var parts []string
+	for _, g := range mappingRuleGroups {
+		if n := len(tm.RulesOfType(g.ruleType)); n > 0 {
+			parts = append(parts, fmt.Sprintf("%s %s", numberStyle.Render(fmt.Sprintf("%d", n)), valueStyle.Render(g.ruleType)))
+		}
+	}
+	if len(parts) == 0 {
+		return mutedTextStyle.Render("no rules")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// mappingLines renders the table mappings of a task as a rule tree or as
+// highlighted JSON, one entry per screen line
+func (m Model) mappingLines(task dms.Task) []string {
+	if m.mappingsJSON {
+		pretty, err := dms.PrettyJSON(task.TableMappings)
+		if err != nil {
+			return []string{errorTextStyle.Render(err.Error()), mutedTextStyle.Render(task.TableMappings)}
+		}
+		lines := strings.Split(pretty, "\n")
+		for i, l := range lines {
+			lines[i] = highlightJSONLine(l)
+		}
+		return lines
+	}
+
+	tm, err := dms.ParseTableMappings(task.TableMappings)
+	if err != nil {
+		return []string{errorTextStyle.Render(err.Error()), mutedTextStyle.Render("Press [v] for the raw JSON.")}
+	}
+	return renderMappingTree(tm)
+}
+
+// renderMappingTree renders the rules grouped by rule type
+func renderMappingTree(tm *dms.TableMappings) []string {
+	var lines []string
+
+	known := make(map[string]bool)
+	for _, g := range mappingRuleGroups {
+		known[g.ruleType] = true
+		lines = append(lines, renderMappingGroup(g.title, tm.RulesOfType(g.ruleType))...)
+	}
+
+	// Rule types added by AWS after this viewer was written
+	var other []dms.MappingRule
+	for _, r := range tm.Rules {
+		if !known[r.Type] {
+			other = append(other, r)
+		}
+	}
+	lines = append(lines, renderMappingGroup("Other rules", other)...)
+
+	if len(tm.Rules) == 0 {
+		lines = append(lines, mutedTextStyle.Render("No rules."))
+	}
+	return lines
+}
+
+func renderMappingGroup(title string, rules []dms.MappingRule) []string {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	lines := []string{sectionHeaderStyle.UnsetMarginTop().Render(fmt.Sprintf("%s (%d)", title, len(rules)))}
+	for i, r := range rules {
+		branch, indent := "├─ ", "│  "
+		if i == len(rules)-1 {
+			branch, indent = "└─ ", "   "
+		}
+
+		head := fmt.Sprintf("%s %s", numberStyle.Render("#"+r.ID), valueStyle.Render(r.Name))
+		if r.Action != "" {
+			action := r.Action
+			if r.Target != "" {
+				action += " → " + r.Target
+			}
+			head += "  " + mappingActionStyle(r.Action).Render(action)
+		}
+		if r.Type != dms.RuleTypeSelection && r.Type != dms.RuleTypeTransformation && r.Type != dms.RuleTypeTableSettings {
+			head += "  " + mutedTextStyle.Render("("+r.Type+")")
+		}
+		lines = append(lines, mutedTextStyle.Render(branch)+head)
+
+		var details []string
+		if len(r.ObjectLocator) > 0 {
+			parts := make([]string, len(r.ObjectLocator))
+			for j, kv := range r.ObjectLocator {
+				parts[j] = fmt.Sprintf("%s %s", labelStyle.Render(strings.TrimSuffix(kv.Key, "-name")+":"), valueStyle.Render(kv.Value))
+			}
+			details = append(details, strings.Join(parts, "  "))
+		}
+		for _, f := range r.Filters {
+			details = append(details, fmt.Sprintf("%s %s %s %s",
+				labelStyle.Render("filter:"),
+				mutedTextStyle.Render(f.Type),
+				valueStyle.Render(f.Column),
+				warningTextStyle.Render(strings.Join(f.Conditions, " or "))))
+		}
+		if r.Value != "" {
+			details = append(details, fmt.Sprintf("%s %s", labelStyle.Render("value:"), valueStyle.Render(r.Value)))
+		}
+		for _, kv := range r.Settings {
+			details = append(details, fmt.Sprintf("%s %s", labelStyle.Render(kv.Key+":"), mutedTextStyle.Render(kv.Value)))
+		}
+
+		for _, d := range details {
+			lines = append(lines, mutedTextStyle.Render(indent)+"  "+d)
+		}
+	}
+	return append(lines, "")
+}
+
+// mappingActionStyle colors rule actions by effect
+func mappingActionStyle(action string) lipglossStyle {
+	switch action {
+	case "include":
+		return statusRunningStyle
+	case "exclude", "remove-column":
+		return statusStoppedStyle
+	default:
+		return statusOtherStyle
+	}
+}
+
+// highlightJSONLine colors one line of indented JSON: keys, strings,
+// numbers and literals
+func highlightJSONLine(line string) string {
+	var sb strings.Builder
+	r := []rune(line)
+
+	for i := 0; i < len(r); {
+		c := r[i]
+		switch {
+		case c == '"':
+			j := i + 1
+			for j < len(r) && r[j] != '"' {
+				if r[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			j = min(j+1, len(r))
+			token := string(r[i:j])
+
+			// A string followed by a colon is a key
+			rest := strings.TrimLeft(string(r[j:]), " ")
+			if strings.HasPrefix(rest, ":") {
+				sb.WriteString(labelStyle.Render(token))
+			} else {
+				sb.WriteString(valueStyle.Render(token))
+			}
+			i = j
+
+		case c == '-' || unicode.IsDigit(c):
+			j := i + 1
+			for j < len(r) && strings.ContainsRune("0123456789.eE+-", r[j]) {
+				j++
+			}
+			sb.WriteString(numberStyle.Render(string(r[i:j])))
+			i = j
+
+		case unicode.IsLetter(c):
+			j := i + 1
+			for j < len(r) && unicode.IsLetter(r[j]) {
+				j++
+			}
+			sb.WriteString(warningTextStyle.Render(string(r[i:j])))
+			i = j
+
+		case strings.ContainsRune("{}[],:", c):
+			sb.WriteString(mutedTextStyle.Render(string(c)))
+			i++
+
+		default:
+			sb.WriteRune(c)
+			i++
+		}
+	}
+	return sb.String()
+}
+
+// mappingsParts splits the table mappings view for the viewport
+func (m Model) mappingsParts() (header, body, help string) {
+	task, ok := m.mappingsTask()
+	if !ok {
+		return "Task not found", "", ""
+	}
+
+	mode := "rules"
+	if m.mappingsJSON {
+		mode = "JSON"
+	}
+	header = titleStyle.Render(fmt.Sprintf("Table Mappings - %s", task.Name)) + "\n" +
+		mutedTextStyle.Render("View: "+mode) + "\n"
+
+	return header, strings.Join(m.mappingLines(task), "\n"), m.renderBindingsHelp(viewMappings)
+}
@@ -2,9 +2,14 @@ package tui
 
 import (
 	"fmt"
+	"maps"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/cursor"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/eljosho/dms-manager/pkg/dms"
 )
@@ -18,38 +23,154 @@ const (
 	viewTableStats
 	viewLoading
 	viewError
+	viewContextPicker
+	viewHelp
+	viewTransitions
+	viewMappings
+	viewCreateTask
+	viewInstances
 )
 
+// throughputSampleInterval is the least time between two samples of the
+// throughput of every listed running task, each one a DescribeTableStatistics
+// call per task
+const throughputSampleInterval = 30 * time.Second
+
 // Model holds the state for the TUI
 type Model struct {
-	client            *dms.Client
-	tasks             []dms.Task
-	tableStats        []dms.TableStatistic
-	cursor            int
-	selected          map[int]bool
-	state             viewState
-	err               error
-	spinner           spinner.Model
-	width             int
-	height            int
-	detailsTaskIdx    int
-	operationMsg      string
-	autoRefresh       bool
-	showExtendedStats bool
+	client        *dms.Client
+	allTasks      []dms.Task
+	tasks         []dms.Task
+	taskFilter    string
+	tableStats    []dms.TableStatistic
+	cursor        int
+	selected      map[string]bool // task ARNs
+	state         viewState
+	err           error
+	retryCmd      tea.Cmd
+	spinner       spinner.Model
+	width         int
+	height        int
+	detailsARN    string
+	operationMsg  string
+	autoRefresh   bool
+	tickPending   bool
+	tickGen       int
+	tableStatsARN string
+	throughput    *dms.ThroughputTracker
+	lastSample    time.Time
+
+	// Background refresh failures, retried with backoff while the last good
+	// task list stays on screen
+	refreshErr  error
+	nextRefresh time.Time
+	scheduler   *dms.RefreshScheduler
+
+	// Status bar state
+	readOnly        bool
+	lastRefresh     time.Time
+	lastRefreshTook time.Duration
+	inFlight        int
+	health          *apiHealth
+
+	// Copy menu
+	copyMenu bool
+
+	// Task creation wizard
+	wizard taskWizard
+
+	// Table mappings view state
+	mappingsJSON bool
+
+	// Endpoints by ARN, for showing task endpoints by name
+	endpoints map[string]dms.Endpoint
+
+	// Replication instances tab
+	instances       []dms.ReplicationInstance
+	instancesCursor int
+	instancesLoaded bool
+	instancesErr    error
+
+	// Scroll position of the details, table statistics and mappings views
+	viewport    viewport.Model
+	viewportKey string
+
+	// Task status transitions observed between refreshes
+	changedTasks map[string]time.Time
+	transitions  []transition
+
+	// Context switching and the merged multi-context view
+	picker        contextPicker
+	pickerReturn  viewState
+	contextStates map[string]contextState
+	pinnedClients map[string]*dms.Client
+	merged        bool
+	taskContexts  map[string]string
+
+	// Command palette and help overlay
+	paletteActive     bool
+	paletteInput      textinput.Model
+	paletteMsg        string
+	paletteHistory    []string
+	paletteHistoryIdx int
+	paletteSel        int
+	helpReturn        viewState
+
+	// Table statistics view state
+	statsSort        tableStatsSort
+	statsSortAsc     bool
+	statsFilter      string
+	statsFilterPrev  string
+	statsFiltering   bool
+	statsFilterInput textinput.Model
+	statsOffset      int
+	statsCursor      int
+	statsSelected    map[string]bool // table keys selected for reloading
+	changedTables    map[string]bool
+	reloadConfirm    *tableReload // table reload waiting for confirmation
+}
+
+// Options configures a TUI model
+type Options struct {
+	// ReadOnly disables task operations
+	ReadOnly bool
+
+	// Refresh controls the auto-refresh interval
+	Refresh dms.RefreshPolicy
 }
 
 // NewModel creates a new TUI model
-func NewModel(client *dms.Client) Model {
+func NewModel(client *dms.Client, opts Options) Model {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = infoStyle
 
+	fi := textinput.New()
+	fi.Prompt = "/"
+	fi.Placeholder = "schema=… table=… state=…"
+	fi.Cursor.SetMode(cursor.CursorStatic)
+
+	pi := textinput.New()
+	pi.Prompt = ":"
+	pi.Placeholder = "command (tab to complete)"
+	pi.Cursor.SetMode(cursor.CursorStatic)
+
 	return Model{
-		client:      client,
-		selected:    make(map[int]bool),
-		state:       viewLoading,
-		spinner:     s,
-		autoRefresh: true,
+		client:           client,
+		selected:         make(map[string]bool),
+		state:            viewLoading,
+		spinner:          s,
+		autoRefresh:      true,
+		statsFilterInput: fi,
+		paletteInput:     pi,
+		viewport:         viewport.New(0, 0),
+		throughput:       dms.NewThroughputTracker(dms.DefaultThroughputWindow),
+		readOnly:         opts.ReadOnly,
+		scheduler:        dms.NewRefreshScheduler(opts.Refresh),
+		health:           &apiHealth{},
+		changedTasks:     make(map[string]time.Time),
+		contextStates:    make(map[string]contextState),
+		pinnedClients:    make(map[string]*dms.Client),
 	}
 }
 
@@ -57,7 +178,9 @@ func NewModel(client *dms.Client) Model {
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
 		m.spinner.Tick,
-		LoadTasksCmd(m.client),
+		m.loadTasksCmd(),
+		LoadEndpointsCmd(m.client),
+		LoadInstancesCmd(m.client),
 	)
 }
 
@@ -67,185 +190,613 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		return m.handleKeyPress(msg)
 
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
+
 	case tea.WindowSizeMsg:
+		wasSplit := m.isSplitView()
 		m.width = msg.Width
 		m.height = msg.Height
+		if !wasSplit && m.state == viewTaskList {
+			return m, m.loadPaneStatsCmd()
+		}
 		return m, nil
 
 	case tasksLoadedMsg:
+		now := time.Now()
+		m.health.record(msg.err, now)
+		for _, err := range msg.failures {
+			m.health.record(err, now)
+		}
+		// Loads started before a context switch or merged toggle are stale;
+		// keep the refresh ticking in case no newer load is in flight
+		if msg.client != m.client || msg.merged != m.merged {
+			return m, m.scheduleTick()
+		}
 		if msg.err != nil {
-			m.state = viewError
-			m.err = msg.err
-			return m, nil
+			if m.state == viewLoading {
+				return m.showError(msg.err, m.loadTasksCmd())
+			}
+			m.refreshErr = msg.err
+			m.scheduler.Fail()
+			return m, m.scheduleTick()
+		}
+		m.refreshErr = nil
+		m.scheduler.Observe(dms.AnyTransitional(msg.tasks), m.lastRefresh.IsZero() || dms.TasksChanged(m.allTasks, msg.tasks))
+		m.lastRefresh = now
+		m.lastRefreshTook = msg.took
+		prev := m.allTasks
+		m.allTasks = msg.tasks
+		m.taskContexts = msg.contexts
+		m.applyTaskFilter()
+		if msg.warning != "" {
+			m.operationMsg = msg.warning
 		}
-		m.tasks = msg.tasks
+		cmds := []tea.Cmd{m.recordTransitions(prev)}
 		if m.state == viewLoading {
 			m.state = viewTaskList
+			// Prime the details pane so it isn't empty until the first tick
+			if m.isSplitView() {
+				cmds = append(cmds, m.loadPaneStatsCmd())
+			}
 		}
-		if m.autoRefresh {
-			return m, TickCmd()
+		cmds = append(cmds, m.scheduleTick())
+		return m, tea.Batch(cmds...)
+
+	case tableStatsLoadedMsg:
+		// Ignore stale results for a task that is no longer being shown
+		if msg.arn != m.tableStatsARN {
+			return m, nil
+		}
+		m.health.record(msg.err, time.Now())
+		if msg.err != nil {
+			// Background refreshes keep the last good stats on screen
+			if m.state == viewLoading {
+				return m.showError(msg.err, m.loadTableStatsCmd(m.detailsARN))
+			}
+			return m, nil
 		}
-		if m.autoRefresh {
-			return m, TickCmd()
+		m.throughput.Record(msg.arn, time.Now(), msg.stats)
+		if m.tableStats != nil {
+			m.changedTables = changedTables(m.tableStats, msg.stats)
+		}
+		m.tableStats = msg.stats
+		if m.state == viewLoading {
+			m.state = viewTableStats
 		}
 		return m, nil
 
-	case tableStatsLoadedMsg:
+	case tablesReloadedMsg:
+		m.health.record(msg.err, time.Now())
 		if msg.err != nil {
-			m.state = viewError
-			m.err = msg.err
+			m.operationMsg = fmt.Sprintf("✗ %v", msg.err)
 			return m, nil
 		}
-		m.tableStats = msg.stats
-		m.state = viewTableStats
+		m.operationMsg = fmt.Sprintf("✓ %s requested for %d table(s)", msg.mode, msg.count)
+		m.statsSelected = nil
+		if m.state == viewTableStats && msg.arn == m.tableStatsARN {
+			return m, m.loadTableStatsCmd(m.detailsARN)
+		}
+		return m, nil
+
+	case throughputSampledMsg:
+		// Failed samples are skipped; the next tick will try again
+		for _, r := range msg.results {
+			m.health.record(r.Error, msg.at)
+			if r.Error == nil {
+				m.throughput.Record(r.TaskARN, msg.at, r.Stats)
+			}
+		}
 		return m, nil
 
 	case taskOperationCompleteMsg:
 		// Show results and reload tasks
+		m.inFlight = max(m.inFlight-len(msg.results), 0)
+		for _, r := range msg.results {
+			m.health.record(r.Error, time.Now())
+		}
 		m.operationMsg = formatOperationResults(msg.results)
-		return m, LoadTasksCmd(m.client)
+		m.scheduler.Reset()
+		return m, m.loadTasksCmd()
+
+	case profilesLoadedMsg:
+		m.picker.profiles = msg.profiles
+		m.picker.err = msg.err
+		if len(m.picker.profiles) == 0 {
+			// Without a config file the default credential chain still works
+			m.picker.profiles = []string{"default"}
+		}
+		m.picker.cursor = indexOf(m.picker.profiles, contextProfile(m.client))
+		return m, nil
 
-	case tickMsg:
-		if m.autoRefresh && m.state == viewTaskList {
-			return m, tea.Batch(LoadTasksCmd(m.client), TickCmd())
+	case contextClientMsg:
+		return m.handleContextClient(msg)
+
+	case wizardResourcesMsg:
+		m.wizard.loading = false
+		m.wizard.instances = msg.instances
+		m.wizard.endpoints = msg.endpoints
+		m.health.record(msg.err, time.Now())
+		if msg.err != nil {
+			m.wizard.err = msg.err
+		} else {
+			// Add to the known endpoints rather than replace them, which
+			// may include those of other contexts
+			if m.endpoints == nil {
+				m.endpoints = make(map[string]dms.Endpoint)
+			}
+			maps.Copy(m.endpoints, dms.EndpointsByARN(msg.endpoints))
+		}
+		return m, nil
+
+	case taskCreatedMsg:
+		return m.handleTaskCreated(msg)
+
+	case instancesLoadedMsg:
+		return m.handleInstancesLoaded(msg)
+
+	case endpointsLoadedMsg:
+		// Task endpoints are shown as ARNs until names are known
+		m.health.record(msg.err, time.Now())
+		if msg.err == nil && msg.client == m.client {
+			m.endpoints = dms.EndpointsByARN(msg.endpoints)
+		}
+		return m, nil
+
+	case consoleLinkMsg:
+		// A failed identifier lookup still yields a link to the list page
+		what := "console link"
+		if msg.err != nil {
+			what = "console link to the list page (identifier lookup failed)"
+		}
+		return m, CopyCmd(msg.url, what, true)
+
+	case copiedMsg:
+		if msg.err != nil {
+			m.operationMsg = msg.err.Error()
+		} else {
+			m.operationMsg = fmt.Sprintf("Copied %s to the clipboard", msg.what)
+			if msg.text != "" {
+				m.operationMsg += ": " + msg.text
+			}
 		}
 		return m, nil
 
+	case exportCompleteMsg:
+		if msg.err != nil {
+			m.operationMsg = msg.err.Error()
+		} else {
+			m.operationMsg = fmt.Sprintf("Exported %d tasks to %s", msg.count, msg.path)
+		}
+		return m, nil
+
+	case tickMsg:
+		// A sooner tick replaced this one
+		if msg.gen != m.tickGen {
+			return m, nil
+		}
+		m.tickPending = false
+		if !m.autoRefresh {
+			return m, nil
+		}
+		switch m.state {
+		case viewTaskList:
+			paneCmd := m.loadPaneStatsCmd()
+			skip := ""
+			if paneCmd != nil {
+				skip = m.tableStatsARN
+			}
+			sampleCmd := m.sampleThroughputCmd(skip)
+			return m, tea.Batch(m.loadTasksCmd(), paneCmd, sampleCmd)
+		case viewTransitions, viewMappings:
+			return m, m.loadTasksCmd()
+		case viewInstances:
+			return m, tea.Batch(m.loadTasksCmd(), LoadInstancesCmd(m.client))
+		case viewTaskDetails, viewTableStats:
+			// The stats of the shown task are its throughput samples
+			statsCmd := m.loadTableStatsCmd(m.detailsARN)
+			return m, tea.Batch(m.loadTasksCmd(), statsCmd)
+		}
+		// Keep the loop alive while a foreground load is in progress
+		return m, m.scheduleTick()
+
 	case spinner.TickMsg:
 		var cmd tea.Cmd
 		m.spinner, cmd = m.spinner.Update(msg)
 		return m, cmd
 
 	case errorMsg:
-		m.state = viewError
-		m.err = msg.err
-		return m, nil
+		return m.showError(msg.err, nil)
 	}
 
 	return m, nil
 }
 
 func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "q":
+	// Text inputs and modal views capture all keys while they are open
+	if m.paletteActive {
+		return m.handlePaletteKeys(msg)
+	}
+	if m.copyMenu {
+		return m.handleCopyMenuKeys(msg)
+	}
+	if m.reloadConfirm != nil {
+		return m.handleReloadConfirmKeys(msg)
+	}
+	if m.statsFiltering {
+		return m.handleStatsFilterKeys(msg)
+	}
+	if m.state == viewHelp {
+		return m.handleHelpKeys(msg)
+	}
+	if m.state == viewCreateTask {
+		return m.handleWizardKeys(msg)
+	}
+	if m.state == viewContextPicker {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m.handleContextPickerKeys(msg)
+	}
+
+	act, ok := lookupAction(m.state, msg.String())
+	if !ok {
+		return m, nil
+	}
+	return m.performAction(act)
+}
+
+// performAction runs an action triggered by a key binding or a palette
+// command
+func (m Model) performAction(act action) (tea.Model, tea.Cmd) {
+	switch act {
+	case actionQuit:
 		return m, tea.Quit
 
-	case "f":
+	case actionRefresh:
 		// Refresh task list (changed from 'r' to avoid conflict with resume)
 		m.operationMsg = ""
-		return m, LoadTasksCmd(m.client)
+		m.scheduler.Reset()
+		return m, m.loadTasksCmd()
 
-	case "a":
-		// Toggle auto-refresh
-		m.autoRefresh = !m.autoRefresh
-		if m.autoRefresh {
-			return m, TickCmd()
+	case actionRetry:
+		if m.state != viewError {
+			return m, nil
 		}
-		return m, nil
-	}
+		m.err = nil
+		m.state = viewLoading
+		if m.retryCmd != nil {
+			return m, m.retryCmd
+		}
+		return m, m.loadTasksCmd()
 
-	switch m.state {
-	case viewTaskList:
-		return m.handleTaskListKeys(msg)
-	case viewTaskDetails:
-		return m.handleTaskDetailsKeys(msg)
-	case viewTableStats:
-		return m.handleTableStatsKeys(msg)
-	}
+	case actionAutoRefresh:
+		m.autoRefresh = !m.autoRefresh
+		return m, m.scheduleTick()
 
-	return m, nil
-}
+	case actionPalette:
+		return m.openPalette()
 
-func (m Model) handleTaskListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "up", "k":
+	case actionHelp:
+		return m.openHelp()
+
+	case actionUp:
+		if m.state == viewInstances {
+			m.instancesCursor = max(m.instancesCursor-1, 0)
+			return m, nil
+		}
 		if m.cursor > 0 {
 			m.cursor--
+			return m, m.loadPaneStatsCmd()
 		}
 
-	case "down", "j":
+	case actionDown:
+		if m.state == viewInstances {
+			m.instancesCursor = max(min(m.instancesCursor+1, len(m.instances)-1), 0)
+			return m, nil
+		}
 		if m.cursor < len(m.tasks)-1 {
 			m.cursor++
+			return m, m.loadPaneStatsCmd()
 		}
 
-	case " ":
-		// Toggle selection
-		if m.selected[m.cursor] {
-			delete(m.selected, m.cursor)
-		} else {
-			m.selected[m.cursor] = true
+	case actionSelect:
+		if m.state == viewTableStats {
+			m.toggleStatsSelection()
+			return m, nil
+		}
+		if m.cursor < len(m.tasks) {
+			m.toggleSelection(m.tasks[m.cursor].ARN)
 		}
 
-	case "enter":
-		// View task details
-		m.detailsTaskIdx = m.cursor
+	case actionDetails:
+		if m.cursor >= len(m.tasks) {
+			return m, nil
+		}
+		m.detailsARN = m.tasks[m.cursor].ARN
 		m.state = viewTaskDetails
 
-	case "s":
-		// Start selected tasks
-		arns := m.getSelectedARNs()
-		if len(arns) > 0 {
-			m.operationMsg = "Starting tasks..."
-			return m, StartTasksCmd(m.client, arns)
+	case actionTableStats:
+		// From the list, show stats for the cursor task; from details, for
+		// the task being viewed
+		if m.state != viewTaskDetails && m.state != viewTableStats {
+			if m.cursor >= len(m.tasks) {
+				return m, nil
+			}
+			m.detailsARN = m.tasks[m.cursor].ARN
+		}
+		m.state = viewLoading
+		return m, m.loadTableStatsCmd(m.detailsARN)
+
+	case actionStart:
+		return m.runTaskOperation("Starting tasks...", StartTasksCmd)
+
+	case actionStop:
+		return m.runTaskOperation("Stopping tasks...", StopTasksCmd)
+
+	case actionResume:
+		return m.runTaskOperation("Resuming tasks...", ResumeTasksCmd)
+
+	case actionReload:
+		return m.runTaskOperation("Reloading tasks...", ReloadTasksCmd)
+
+	case actionClear:
+		if m.state == viewTableStats {
+			m.statsSelected = nil
+			return m, nil
+		}
+		m.selected = make(map[string]bool)
+
+	case actionContext:
+		return m.openContextPicker()
+
+	case actionTransitions:
+		m.state = viewTransitions
+
+	case actionMerged:
+		if len(m.pinnedClients) == 0 {
+			m.operationMsg = "Pin contexts with [space] in the context picker ([P]) to merge them"
+			return m, nil
 		}
+		m.merged = !m.merged
+		m.selected = make(map[string]bool)
+		m.state = viewLoading
+		return m, m.loadTasksCmd()
 
-	case "x":
-		// Stop selected tasks
-		arns := m.getSelectedARNs()
-		if len(arns) > 0 {
-			m.operationMsg = "Stopping tasks..."
-			return m, StopTasksCmd(m.client, arns)
+	case actionBack:
+		switch m.state {
+		case viewTaskDetails:
+			m.state = viewTaskList
+		case viewTableStats:
+			m.state = viewTaskDetails
+		case viewTransitions:
+			m.state = viewTaskList
+		case viewMappings:
+			m.state = viewTaskDetails
+		case viewInstances:
+			m.state = viewTaskList
 		}
 
-	case "r":
-		// Resume selected tasks
-		arns := m.getSelectedARNs()
-		if len(arns) > 0 {
-			m.operationMsg = "Resuming tasks..."
-			return m, ResumeTasksCmd(m.client, arns)
+	case actionSwitchTab:
+		if m.state == viewInstances {
+			m.state = viewTaskList
+			return m, m.loadPaneStatsCmd()
 		}
+		return m.openInstances()
 
-	case "l":
-		// Reload selected tasks
-		arns := m.getSelectedARNs()
-		if len(arns) > 0 {
-			m.operationMsg = "Reloading tasks..."
-			return m, ReloadTasksCmd(m.client, arns)
+	case actionMappings:
+		if task, ok := m.mappingsTask(); !ok || task.TableMappings == "" {
+			m.operationMsg = "Task has no table mappings"
+			return m, nil
 		}
+		m.state = viewMappings
 
-	case "c":
-		// Clear selection
-		m.selected = make(map[int]bool)
+	case actionLineUp, actionLineDown, actionPageUp, actionPageDown, actionTop, actionBottom:
+		if m.state == viewTableStats {
+			m.moveStatsCursor(act)
+			return m, nil
+		}
+		m.scroll(act)
+
+	case actionToggleJSON:
+		m.mappingsJSON = !m.mappingsJSON
+		m.viewport.GotoTop()
+
+	case actionCopyMenu:
+		return m.openCopyMenu()
+
+	case actionCreate:
+		return m.openWizard()
+
+	case actionCopy:
+		task, ok := m.mappingsTask()
+		if !ok {
+			return m, nil
+		}
+		text, err := dms.PrettyJSON(task.TableMappings)
+		if err != nil {
+			text = task.TableMappings
+		}
+		return m, CopyCmd(text, "table mappings", false)
+
+	case actionSort:
+		m.statsSort = (m.statsSort + 1) % sortColumnCount
+
+	case actionReverseSort:
+		m.statsSortAsc = !m.statsSortAsc
+
+	case actionFilter:
+		m.statsFiltering = true
+		m.statsFilterPrev = m.statsFilter
+		m.statsFilterInput.SetValue(m.statsFilter)
+		m.statsFilterInput.CursorEnd()
+		return m, m.statsFilterInput.Focus()
+
+	case actionScrollLeft:
+		if m.statsOffset > 0 {
+			m.statsOffset--
+		}
+
+	case actionScrollRight:
+		if m.statsOffset < m.maxStatsOffset() {
+			m.statsOffset++
+		}
+
+	case actionScrollHome:
+		m.statsOffset = 0
+
+	case actionReloadTables:
+		return m.reloadTables(dms.ReloadData)
+
+	case actionValidateTables:
+		return m.reloadTables(dms.ReloadValidateOnly)
 	}
 
 	return m, nil
 }
 
-func (m Model) handleTaskDetailsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+func (m Model) handleStatsFilterKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
-	case "esc", "backspace":
-		m.state = viewTaskList
-	case "t":
-		// Toggle extended stats view
-		m.showExtendedStats = !m.showExtendedStats
-
-	case "T":
-		// View table statistics
-		m.state = viewLoading
-		return m, LoadTableStatsCmd(m.client, m.tasks[m.detailsTaskIdx].ARN)
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "enter":
+		m.statsFiltering = false
+		m.statsFilterInput.Blur()
+		return m, nil
+
+	case "esc":
+		// Discard the edit and restore the previous filter
+		m.statsFiltering = false
+		m.statsFilter = m.statsFilterPrev
+		m.statsFilterInput.Blur()
+		return m, nil
 	}
 
-	return m, nil
+	// Filter as you type
+	var cmd tea.Cmd
+	m.statsFilterInput, cmd = m.statsFilterInput.Update(msg)
+	m.statsFilter = m.statsFilterInput.Value()
+	return m, cmd
 }
 
-func (m Model) handleTableStatsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc", "backspace", "q":
-		m.state = viewTaskDetails
+// maxStatsOffset is the furthest the name columns of the table statistics
+// view can be scrolled to the right
+func (m Model) maxStatsOffset() int {
+	longest := 0
+	for _, s := range m.tableStats {
+		if n := len([]rune(s.SchemaName)); n > longest {
+			longest = n
+		}
+		if n := len([]rune(s.TableName)); n > longest {
+			longest = n
+		}
+	}
+	return longest
+}
+
+// scheduleTick starts the next auto-refresh tick. The scheduler picks the
+// delay from the outcome of recent refreshes; a pending tick is only
+// replaced when the new delay would fire sooner, e.g. once a task starts.
+func (m *Model) scheduleTick() tea.Cmd {
+	if !m.autoRefresh {
+		return nil
 	}
+	d := m.scheduler.Next()
+	next := time.Now().Add(d)
+	if m.tickPending && !next.Before(m.nextRefresh) {
+		return nil
+	}
+	m.tickGen++
+	m.tickPending = true
+	m.nextRefresh = next
+	return TickCmd(d, m.tickGen)
+}
+
+// showError switches to the error view. retry re-runs the failed load when
+// the user retries; nil reloads the task list.
+func (m Model) showError(err error, retry tea.Cmd) (tea.Model, tea.Cmd) {
+	m.state = viewError
+	m.err = err
+	m.retryCmd = retry
 	return m, nil
 }
 
+// detailsTask returns the task shown in the details views. It is looked up
+// among all loaded tasks, so it stays shown when a filter hides it.
+func (m Model) detailsTask() (dms.Task, bool) {
+	for _, task := range m.allTasks {
+		if task.ARN == m.detailsARN {
+			return task, true
+		}
+	}
+	return dms.Task{}, false
+}
+
+// loadTableStatsCmd loads table statistics for a task and marks it as the
+// task whose stats are currently displayed
+func (m *Model) loadTableStatsCmd(arn string) tea.Cmd {
+	if arn == "" {
+		return nil
+	}
+	if arn != m.tableStatsARN {
+		m.tableStats = nil
+		m.tableStatsARN = arn
+		m.changedTables = nil
+		m.statsOffset = 0
+		m.statsCursor = 0
+		m.statsSelected = nil
+	}
+	return LoadTableStatsCmd(m.clientFor(arn), arn)
+}
+
+// loadPaneStatsCmd refreshes the table stats shown in the split-view details
+// pane for the cursor task. It is a no-op on narrow terminals.
+func (m *Model) loadPaneStatsCmd() tea.Cmd {
+	if !m.isSplitView() || m.cursor >= len(m.tasks) {
+		return nil
+	}
+	return m.loadTableStatsCmd(m.tasks[m.cursor].ARN)
+}
+
+// sampleThroughputCmd samples table statistics of every listed running task
+// except skip, whose stats are already being refreshed for display. It
+// samples at most once per throughputSampleInterval to spare the API quota.
+func (m *Model) sampleThroughputCmd(skip string) tea.Cmd {
+	now := time.Now()
+	if now.Sub(m.lastSample) < throughputSampleInterval {
+		return nil
+	}
+	m.lastSample = now
+
+	var arns []string
+	for _, task := range m.tasks {
+		if strings.ToLower(task.Status) == "running" && task.ARN != skip {
+			arns = append(arns, task.ARN)
+		}
+	}
+	if len(arns) == 0 {
+		return nil
+	}
+	return m.forEachClient(arns, SampleThroughputCmd)
+}
+
+// runTaskOperation sends a task operation for the selected tasks to the
+// contexts owning them, unless the TUI is read-only
+func (m Model) runTaskOperation(progress string, build func(*dms.Client, []string) tea.Cmd) (tea.Model, tea.Cmd) {
+	if m.readOnly {
+		m.operationMsg = "Read-only mode: task operations are disabled"
+		return m, nil
+	}
+	arns := m.getSelectedARNs()
+	if len(arns) == 0 {
+		return m, nil
+	}
+	m.operationMsg = progress
+	m.inFlight += len(arns)
+	return m, m.forEachClient(arns, build)
+}
+
 func (m Model) getSelectedARNs() []string {
 	if len(m.selected) == 0 {
 		// If nothing selected, use cursor position
@@ -255,15 +806,25 @@ func (m Model) getSelectedARNs() []string {
 		return nil
 	}
 
+	// Only listed tasks count, in list order
 	arns := make([]string, 0, len(m.selected))
-	for idx := range m.selected {
-		if idx < len(m.tasks) {
-			arns = append(arns, m.tasks[idx].ARN)
+	for _, task := range m.tasks {
+		if m.selected[task.ARN] {
+			arns = append(arns, task.ARN)
 		}
 	}
 	return arns
 }
 
+// toggleSelection selects or deselects a task
+func (m *Model) toggleSelection(arn string) {
+	if m.selected[arn] {
+		delete(m.selected, arn)
+	} else {
+		m.selected[arn] = true
+	}
+}
+
 func formatOperationResults(results []dms.TaskOperation) string {
 	var sb strings.Builder
 	successCount := 0
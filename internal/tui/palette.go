@@ -0,0 +1,442 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// paletteHistorySize caps the number of remembered palette commands
+const paletteHistorySize = 50
+
+// paletteSuggestions is the number of suggestions shown under the input
+const paletteSuggestions = 6
+
+// paletteCommand is a command that can be run from the ":" palette.
+// Commands that change tasks only run when named exactly or by a unique
+// prefix, never by a fuzzy match.
+type paletteCommand struct {
+	name         string
+	args         string
+	help         string
+	changesTasks bool
+	run          func(m Model, args []string) (tea.Model, tea.Cmd)
+}
+
+// actionCommand adapts a keyboard action into a palette command
+func actionCommand(name, help string, act action) paletteCommand {
+	return paletteCommand{
+		name: name,
+		help: help,
+		run: func(m Model, _ []string) (tea.Model, tea.Cmd) {
+			return m.performAction(act)
+		},
+	}
+}
+
+// taskCommand adapts a keyboard action that changes tasks into a palette
+// command
+func taskCommand(name, help string, act action) paletteCommand {
+	c := actionCommand(name, help, act)
+	c.changesTasks = true
+	return c
+}
+
+// paletteCommands is the registry of palette commands
+var paletteCommands = []paletteCommand{
+	taskCommand("start", "Start selected tasks", actionStart),
+	taskCommand("stop", "Stop selected tasks", actionStop),
+	taskCommand("resume", "Resume selected tasks", actionResume),
+	taskCommand("reload", "Reload selected tasks (reload-target)", actionReload),
+	actionCommand("refresh", "Refresh the task list", actionRefresh),
+	actionCommand("details", "Show details of the cursor task", actionDetails),
+	actionCommand("tables", "Show table statistics of the cursor task", actionTableStats),
+	taskCommand("reload-tables", "Reload the selected tables in table stats", actionReloadTables),
+	taskCommand("validate-tables", "Revalidate the selected tables in table stats", actionValidateTables),
+	actionCommand("mappings", "Show table mappings of the task in details", actionMappings),
+	actionCommand("merged", "Toggle the merged multi-context view", actionMerged),
+	actionCommand("transitions", "Show the task transitions log", actionTransitions),
+	actionCommand("create", "Create a replication task with the wizard", actionCreate),
+	actionCommand("instances", "Switch between the tasks and replication instances tabs", actionSwitchTab),
+	actionCommand("help", "Show keyboard shortcuts and commands", actionHelp),
+	actionCommand("quit", "Quit", actionQuit),
+	{
+		name: "filter",
+		args: "[expr]",
+		help: "Filter tasks (status= name= type= context=) or, in table stats, tables; no expr clears",
+		run:  runFilterCommand,
+	},
+	{
+		name: "context",
+		args: "<profile>[/<region>]",
+		help: "Switch AWS profile and region; no argument opens the picker",
+		run:  runContextCommand,
+	},
+	{
+		name: "export",
+		args: "csv|json [path]",
+		help: "Export the visible tasks to a file",
+		run:  runExportCommand,
+	},
+	{
+		name: "copy",
+		args: "arn|name|source|target|instance|summary",
+		help: "Copy a field of the current task to the clipboard",
+		run:  runCopyCommand,
+	},
+	{
+		name: "console",
+		args: "[task|instance|source|target]",
+		help: "Copy the AWS console link of the current task or its resources",
+		run:  runConsoleCommand,
+	},
+	{
+		name: "auto-refresh",
+		args: "[on|off]",
+		help: "Set or toggle auto-refresh",
+		run:  runAutoRefreshCommand,
+	},
+}
+
+// openPalette shows the command palette over the current view
+func (m Model) openPalette() (tea.Model, tea.Cmd) {
+	m.paletteActive = true
+	m.paletteMsg = ""
+	m.paletteHistoryIdx = len(m.paletteHistory)
+	m.paletteSel = -1
+	m.paletteInput.SetValue("")
+	return m, m.paletteInput.Focus()
+}
+
+func (m Model) closePalette() Model {
+	m.paletteActive = false
+	m.paletteInput.Blur()
+	return m
+}
+
+func (m Model) handlePaletteKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "esc":
+		return m.closePalette(), nil
+
+	case "enter":
+		line := strings.TrimSpace(m.paletteInput.Value())
+		if line == "" {
+			return m.closePalette(), nil
+		}
+		return m.runPaletteLine(line)
+
+	case "tab", "shift+tab":
+		// Complete the highlighted suggestion, cycling on repeat
+		matches := matchPaletteCommands(paletteWord(m.paletteInput.Value()))
+		if len(matches) == 0 {
+			return m, nil
+		}
+		switch {
+		case m.paletteSel < 0:
+			m.paletteSel = 0
+		case msg.String() == "tab":
+			m.paletteSel = (m.paletteSel + 1) % len(matches)
+		default:
+			m.paletteSel = (m.paletteSel - 1 + len(matches)) % len(matches)
+		}
+		m.paletteInput.SetValue(matches[m.paletteSel].name + " ")
+		m.paletteInput.CursorEnd()
+		return m, nil
+
+	case "up":
+		// Walk back through history
+		if m.paletteHistoryIdx > 0 {
+			m.paletteHistoryIdx--
+			m.paletteInput.SetValue(m.paletteHistory[m.paletteHistoryIdx])
+			m.paletteInput.CursorEnd()
+		}
+		return m, nil
+
+	case "down":
+		if m.paletteHistoryIdx < len(m.paletteHistory)-1 {
+			m.paletteHistoryIdx++
+			m.paletteInput.SetValue(m.paletteHistory[m.paletteHistoryIdx])
+		} else {
+			m.paletteHistoryIdx = len(m.paletteHistory)
+			m.paletteInput.SetValue("")
+		}
+		m.paletteInput.CursorEnd()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.paletteInput, cmd = m.paletteInput.Update(msg)
+	m.paletteSel = -1
+	m.paletteMsg = ""
+	return m, cmd
+}
+
+// runPaletteLine records a command line in history and runs it. The command
+// name may be abbreviated to a unique prefix or, unless the best match
+// changes tasks, to anything that fuzzy-matches it.
+func (m Model) runPaletteLine(line string) (tea.Model, tea.Cmd) {
+	if n := len(m.paletteHistory); n == 0 || m.paletteHistory[n-1] != line {
+		m.paletteHistory = append(m.paletteHistory, line)
+		if len(m.paletteHistory) > paletteHistorySize {
+			m.paletteHistory = m.paletteHistory[len(m.paletteHistory)-paletteHistorySize:]
+		}
+	}
+	m.paletteHistoryIdx = len(m.paletteHistory)
+
+	fields := strings.Fields(line)
+	name := fields[0]
+
+	cmd, fuzzy, ok := findPaletteCommand(name)
+	if !ok {
+		m.paletteMsg = fmt.Sprintf("Unknown command: %s", name)
+		return m, nil
+	}
+	if fuzzy && cmd.changesTasks {
+		// Leave the line and the matching commands on screen to pick from
+		m.paletteMsg = fmt.Sprintf("Ambiguous command: %s; type more of the name or press tab", name)
+		return m, nil
+	}
+
+	return cmd.run(m.closePalette(), fields[1:])
+}
+
+// findPaletteCommand returns the command with the given name or the only
+// command it is a prefix of, or else the best fuzzy match, reported by fuzzy
+func findPaletteCommand(name string) (cmd paletteCommand, fuzzy, ok bool) {
+	var prefixed []paletteCommand
+	for _, c := range paletteCommands {
+		if c.name == name {
+			return c, false, true
+		}
+		if strings.HasPrefix(c.name, name) {
+			prefixed = append(prefixed, c)
+		}
+	}
+	if len(prefixed) == 1 {
+		return prefixed[0], false, true
+	}
+	matches := matchPaletteCommands(name)
+	if len(matches) == 0 {
+		return paletteCommand{}, false, false
+	}
+	return matches[0], true, true
+}
+
+// matchPaletteCommands returns the commands fuzzy-matching pattern, best
+// match first. An empty pattern matches every command.
+func matchPaletteCommands(pattern string) []paletteCommand {
+	type scored struct {
+		cmd   paletteCommand
+		score int
+	}
+
+	var matches []scored
+	for _, c := range paletteCommands {
+		if score, ok := fuzzyScore(pattern, c.name); ok {
+			matches = append(matches, scored{c, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	cmds := make([]paletteCommand, len(matches))
+	for i, s := range matches {
+		cmds[i] = s.cmd
+	}
+	return cmds
+}
+
+// fuzzyScore reports whether the characters of pattern appear in order in s
+// and scores the match, favoring matches at the start of s and runs of
+// consecutive characters
+func fuzzyScore(pattern, s string) (int, bool) {
+	pattern = strings.ToLower(pattern)
+	s = strings.ToLower(s)
+
+	score, pi, run := 0, 0, 0
+	p := []rune(pattern)
+	for i, r := range s {
+		if pi == len(p) {
+			break
+		}
+		if r != p[pi] {
+			run = 0
+			continue
+		}
+		run++
+		score += run
+		if i == 0 {
+			score += 5
+		}
+		pi++
+	}
+	if pi < len(p) {
+		return 0, false
+	}
+	// Shorter names win ties so that exact prefixes rank first
+	return score*100 - len(s), true
+}
+
+// paletteWord returns the command word of the palette input
+func paletteWord(line string) string {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+func runFilterCommand(m Model, args []string) (tea.Model, tea.Cmd) {
+	expr := strings.Join(args, " ")
+	if m.state == viewTableStats {
+		m.statsFilter = expr
+		return m, nil
+	}
+	m.taskFilter = expr
+	m.selected = make(map[string]bool)
+	m.applyTaskFilter()
+	return m, m.loadPaneStatsCmd()
+}
+
+func runContextCommand(m Model, args []string) (tea.Model, tea.Cmd) {
+	if len(args) == 0 {
+		return m.openContextPicker()
+	}
+	profile, region, _ := strings.Cut(args[0], "/")
+	m.operationMsg = fmt.Sprintf("Switching to %s...", args[0])
+	return m, NewClientCmd(profile, region, false)
+}
+
+func runExportCommand(m Model, args []string) (tea.Model, tea.Cmd) {
+	if len(args) == 0 || (args[0] != "csv" && args[0] != "json") {
+		m.operationMsg = "Usage: :export csv|json [path]"
+		return m, nil
+	}
+	path := ""
+	if len(args) > 1 {
+		path = args[1]
+	}
+	return m, ExportTasksCmd(m.tasks, m.taskContexts, args[0], path)
+}
+
+func runAutoRefreshCommand(m Model, args []string) (tea.Model, tea.Cmd) {
+	if len(args) == 0 {
+		return m.performAction(actionAutoRefresh)
+	}
+	switch args[0] {
+	case "on":
+		m.autoRefresh = true
+	case "off":
+		m.autoRefresh = false
+	default:
+		m.operationMsg = "Usage: :auto-refresh [on|off]"
+	}
+	return m, m.scheduleTick()
+}
+
+// renderPalette renders the palette input with matching commands below it
+func (m Model) renderPalette() string {
+	var sb strings.Builder
+
+	sb.WriteString(m.paletteInput.View())
+	sb.WriteString("\n")
+
+	if m.paletteMsg != "" {
+		sb.WriteString(errorTextStyle.Render(m.paletteMsg))
+		sb.WriteString("\n")
+	}
+
+	matches := matchPaletteCommands(paletteWord(m.paletteInput.Value()))
+	for i, c := range matches {
+		if i == paletteSuggestions {
+			break
+		}
+		nameStyle := normalItemStyle
+		cursor := "  "
+		if i == m.paletteSel {
+			nameStyle = selectedItemStyle
+			cursor = selectedCursorStyle.Render("→ ")
+		}
+		usage := c.name
+		if c.args != "" {
+			usage += " " + c.args
+		}
+		sb.WriteString(fmt.Sprintf("%s%s  %s\n", cursor, nameStyle.Render(fmt.Sprintf("%-28s", usage)), mutedTextStyle.Render(c.help)))
+	}
+
+	sb.WriteString(helpStyle.Render("[enter] run • [tab] complete • [↑/↓] history • [ESC] close"))
+
+	return paletteStyle.Render(sb.String())
+}
+
+// openHelp shows the help overlay
+func (m Model) openHelp() (tea.Model, tea.Cmd) {
+	if m.state != viewHelp {
+		m.helpReturn = m.state
+	}
+	m.state = viewHelp
+	return m, nil
+}
+
+func (m Model) handleHelpKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "?", "q", "backspace":
+		m.state = m.helpReturn
+	}
+	return m, nil
+}
+
+// renderHelpOverlay renders every key binding and palette command, generated
+// from the keymap and palette registries
+func (m Model) renderHelpOverlay() string {
+	var sb strings.Builder
+
+	sb.WriteString(titleStyle.Render("Keyboard Shortcuts"))
+	sb.WriteString("\n")
+
+	// Bindings are laid out in two columns to keep the overlay on screen
+	writeBindings := func(col *strings.Builder, title string, bindings []binding) {
+		col.WriteString(sectionHeaderStyle.Render(title))
+		col.WriteString("\n")
+		for _, b := range bindings {
+			col.WriteString(fmt.Sprintf("  %s %s\n", labelStyle.Render(fmt.Sprintf("%-18s", b.keyLabel())), valueStyle.Render(b.help)))
+		}
+	}
+
+	var left, right strings.Builder
+	writeBindings(&left, viewTitles[viewTaskList], viewBindings[viewTaskList])
+	writeBindings(&left, viewTitles[viewInstances], viewBindings[viewInstances])
+	writeBindings(&left, "Everywhere", globalBindings)
+	writeBindings(&right, viewTitles[viewTaskDetails], viewBindings[viewTaskDetails])
+	writeBindings(&right, viewTitles[viewTableStats], viewBindings[viewTableStats])
+	writeBindings(&right, viewTitles[viewMappings], viewBindings[viewMappings])
+	writeBindings(&right, viewTitles[viewTransitions], viewBindings[viewTransitions])
+	sb.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, left.String(), "    ", right.String()))
+	sb.WriteString("\n")
+
+	sb.WriteString(sectionHeaderStyle.Render("Commands (press : to open)"))
+	sb.WriteString("\n")
+	for _, c := range paletteCommands {
+		usage := ":" + c.name
+		if c.args != "" {
+			usage += " " + c.args
+		}
+		sb.WriteString(fmt.Sprintf("  %s %s\n", labelStyle.Render(fmt.Sprintf("%-30s", usage)), valueStyle.Render(c.help)))
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(helpStyle.Render("Press [ESC] or [?] to close"))
+	sb.WriteString("\n")
+
+	return sb.String()
+}
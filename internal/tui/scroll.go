@@ -0,0 +1,214 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// wheelLines is the number of lines scrolled per mouse wheel step
+const wheelLines = 3
+
+// isScrollable reports whether a view renders its body in the viewport
+func isScrollable(state viewState) bool {
+	switch state {
+	case viewTaskDetails, viewTableStats, viewMappings:
+		return true
+	default:
+		return false
+	}
+}
+
+// screenWidth returns the terminal width, assuming 120 columns until the
+// first resize
+func (m Model) screenWidth() int {
+	if m.width == 0 {
+		return 120
+	}
+	return m.width
+}
+
+// screenHeight returns the terminal height, assuming 40 rows until the first
+// resize
+func (m Model) screenHeight() int {
+	if m.height == 0 {
+		return 40
+	}
+	return m.height
+}
+
+// scrollParts splits the current scrollable view into a fixed header, the
+// scrolled body and the key bindings help shown below it
+func (m Model) scrollParts() (header, body, help string) {
+	switch m.state {
+	case viewTaskDetails:
+		return m.taskDetailsParts()
+	case viewTableStats:
+		return m.tableStatsParts()
+	case viewMappings:
+		return m.mappingsParts()
+	default:
+		return "", "", ""
+	}
+}
+
+// scrollFooter renders everything below the viewport: the operation message,
+// refresh failures, the help and the status bar
+func (m Model) scrollFooter(help string) string {
+	var sb strings.Builder
+	if m.operationMsg != "" {
+		sb.WriteString(infoStyle.Render(m.operationMsg))
+	}
+	sb.WriteString(m.renderRefreshStatus())
+	sb.WriteString(help)
+	sb.WriteString(m.renderStatusBar())
+	return sb.String()
+}
+
+// syncViewport sizes the viewport to the rows left between the header and
+// footer of the current view and loads its body. The scroll position is
+// reset whenever a different view or task is shown.
+func (m *Model) syncViewport() (header, footer string) {
+	header, body, help := m.scrollParts()
+	footer = m.scrollFooter(help)
+
+	key := fmt.Sprintf("%d", m.state)
+	if m.detailsARN != "" {
+		key += "/" + m.detailsARN
+	}
+	if key != m.viewportKey {
+		m.viewportKey = key
+		m.viewport.GotoTop()
+	}
+
+	m.viewport.Width = m.screenWidth()
+	m.viewport.Height = max(m.screenHeight()-lipgloss.Height(header)-lipgloss.Height(footer), 3)
+	m.viewport.SetContent(strings.TrimRight(body, "\n"))
+
+	return header, footer
+}
+
+// renderScrollView renders a view whose body scrolls between a fixed header
+// and footer
+func (m Model) renderScrollView() string {
+	header, footer := m.syncViewport()
+
+	// Leave room for the palette, copy menu or confirmation drawn below the
+	// view
+	overlay := 0
+	if m.paletteActive {
+		overlay = lipgloss.Height(m.renderPalette()) + 1
+	} else if m.copyMenu {
+		overlay = lipgloss.Height(m.renderCopyMenu()) + 1
+	} else if m.reloadConfirm != nil {
+		overlay = lipgloss.Height(m.renderReloadConfirm()) + 1
+	}
+	m.viewport.Height = max(m.viewport.Height-overlay, 3)
+
+	// The scroll position replaces the blank line the footer starts with
+	position := ""
+	if total := m.viewport.TotalLineCount(); total > m.viewport.Height {
+		position = mutedTextStyle.Render(fmt.Sprintf("lines %d-%d of %d (%.0f%%)",
+			m.viewport.YOffset+1, min(m.viewport.YOffset+m.viewport.Height, total), total, m.viewport.ScrollPercent()*100))
+	}
+	if first, rest, ok := strings.Cut(footer, "\n"); ok && strings.TrimSpace(first) == "" {
+		footer = "\n" + rest
+	}
+
+	return header + "\n" + m.viewport.View() + "\n" + position + footer
+}
+
+// scroll moves the viewport of the current view
+func (m *Model) scroll(act action) {
+	if !isScrollable(m.state) {
+		return
+	}
+	m.syncViewport()
+
+	switch act {
+	case actionLineUp:
+		m.viewport.ScrollUp(1)
+	case actionLineDown:
+		m.viewport.ScrollDown(1)
+	case actionPageUp:
+		m.viewport.PageUp()
+	case actionPageDown:
+		m.viewport.PageDown()
+	case actionTop:
+		m.viewport.GotoTop()
+	case actionBottom:
+		m.viewport.GotoBottom()
+	}
+}
+
+// handleMouse scrolls with the wheel and moves the cursor to clicked task
+// rows. Clicking a checkbox toggles the task's selection.
+func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.paletteActive || m.copyMenu || m.reloadConfirm != nil || m.statsFiltering || msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		if isScrollable(m.state) {
+			m.syncViewport()
+			m.viewport.ScrollUp(wheelLines)
+			return m, nil
+		}
+		if m.state == viewTaskList {
+			return m.performAction(actionUp)
+		}
+
+	case tea.MouseButtonWheelDown:
+		if isScrollable(m.state) {
+			m.syncViewport()
+			m.viewport.ScrollDown(wheelLines)
+			return m, nil
+		}
+		if m.state == viewTaskList {
+			return m.performAction(actionDown)
+		}
+
+	case tea.MouseButtonLeft:
+		if m.state == viewTaskList {
+			return m.clickTaskRow(msg.X, msg.Y)
+		}
+	}
+
+	return m, nil
+}
+
+// clickTaskRow handles a click at a screen position of the task list
+func (m Model) clickTaskRow(x, y int) (tea.Model, tea.Cmd) {
+	// Rows start below the header, and inside the left pane's border and
+	// padding in the split layout
+	top := strings.Count(m.renderTaskListHeader(), "\n")
+	left := 0
+	if m.isSplitView() {
+		if x >= m.width*45/100 {
+			return m, nil
+		}
+		top++
+		left = 2
+	}
+
+	// The terminal only shows the bottom of a view taller than the screen
+	y += max(lipgloss.Height(m.View())-m.screenHeight(), 0)
+
+	idx := y - top
+	if idx < 0 || idx >= len(m.tasks) {
+		return m, nil
+	}
+
+	// Each row starts with the 2-column cursor and a 3-column checkbox
+	if x >= left+2 && x < left+5 {
+		m.toggleSelection(m.tasks[idx].ARN)
+	}
+	if idx == m.cursor {
+		return m, nil
+	}
+	m.cursor = idx
+	return m, m.loadPaneStatsCmd()
+}
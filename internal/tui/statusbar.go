@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/eljosho/dms-manager/pkg/dms"
+)
+
+// apiHealthWindow is how far back API errors are counted in the status bar
+const apiHealthWindow = 5 * time.Minute
+
+// apiHealth remembers recent API failures for the status bar
+type apiHealth struct {
+	errors    []time.Time
+	throttles []time.Time
+}
+
+// record notes a failed API call; nil errors are ignored
+func (h *apiHealth) record(err error, at time.Time) {
+	if err == nil {
+		return
+	}
+	if dms.IsThrottling(err) {
+		h.throttles = append(pruneBefore(h.throttles, at.Add(-apiHealthWindow)), at)
+		return
+	}
+	h.errors = append(pruneBefore(h.errors, at.Add(-apiHealthWindow)), at)
+}
+
+// counts returns the number of errors and throttles within the window
+func (h *apiHealth) counts(now time.Time) (int, int) {
+	h.errors = pruneBefore(h.errors, now.Add(-apiHealthWindow))
+	h.throttles = pruneBefore(h.throttles, now.Add(-apiHealthWindow))
+	return len(h.errors), len(h.throttles)
+}
+
+// pruneBefore drops the times earlier than cutoff from a sorted slice
+func pruneBefore(times []time.Time, cutoff time.Time) []time.Time {
+	i := 0
+	for i < len(times) && times[i].Before(cutoff) {
+		i++
+	}
+	return times[i:]
+}
+
+// renderStatusBar renders the bottom status bar: context, data age, refresh
+// timing, pending operations and recent API health
+func (m Model) renderStatusBar() string {
+	now := time.Now()
+	var parts []string
+
+	ctx := clientContextName(m.client)
+	if m.merged {
+		ctx = fmt.Sprintf("%d contexts (merged)", len(m.contextClients()))
+	}
+	parts = append(parts, statusBarKeyStyle.Render(ctx))
+
+	if m.readOnly {
+		parts = append(parts, statusBarWarnStyle.Render("READ-ONLY"))
+	}
+
+	if m.lastRefresh.IsZero() {
+		parts = append(parts, "not refreshed yet")
+	} else {
+		parts = append(parts, fmt.Sprintf("updated %s (%s ago, took %s)",
+			m.lastRefresh.Format("15:04:05"),
+			now.Sub(m.lastRefresh).Round(time.Second),
+			m.lastRefreshTook.Round(time.Millisecond)))
+	}
+
+	switch {
+	case !m.autoRefresh:
+		parts = append(parts, "auto-refresh off")
+	case m.tickPending:
+		next := fmt.Sprintf("next in %s", max(time.Until(m.nextRefresh).Round(time.Second), 0))
+		if mode := m.scheduler.Mode(); mode != "" {
+			next += " (" + mode + ")"
+		}
+		parts = append(parts, next)
+	default:
+		parts = append(parts, "refreshing…")
+	}
+
+	if m.inFlight > 0 {
+		parts = append(parts, statusBarWarnStyle.Render(fmt.Sprintf("%d ops in flight", m.inFlight)))
+	}
+
+	errs, throttles := m.health.counts(now)
+	if errs > 0 || throttles > 0 {
+		parts = append(parts, statusBarWarnStyle.Render(fmt.Sprintf("API errors %d • throttled %d (5m)", errs, throttles)))
+	} else {
+		parts = append(parts, "API ok")
+	}
+
+	width := m.width
+	if width == 0 {
+		width = 120
+	}
+	bar := strings.Join(parts, statusBarSepStyle.Render(" │ "))
+	return "\n" + statusBarStyle.Render(ansi.Truncate(bar, width, "…"))
+}
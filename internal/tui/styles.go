@@ -5,124 +5,275 @@ import "github.com/charmbracelet/lipgloss"
 // lipglossStyle is a type alias for lipgloss.Style
 type lipglossStyle = lipgloss.Style
 
+// sparklineWidth is the number of samples shown in throughput sparklines
+const sparklineWidth = 12
+
+// Theme is a set of colors used by the TUI and the CLI output
+type Theme struct {
+	Primary   lipgloss.Color
+	Secondary lipgloss.Color
+	Success   lipgloss.Color
+	Error     lipgloss.Color
+	Warning   lipgloss.Color
+	Muted     lipgloss.Color
+	Accent    lipgloss.Color
+	Highlight lipgloss.Color
+	Info      lipgloss.Color
+	Text      lipgloss.Color
+	Number    lipgloss.Color
+}
+
+// Themes holds the built-in themes by name
+var Themes = map[string]Theme{
+	"default": {
+		Primary:   "86",  // Cyan
+		Secondary: "212", // Pink
+		Success:   "42",  // Green
+		Error:     "196", // Red
+		Warning:   "220", // Yellow
+		Muted:     "241", // Gray
+		Accent:    "147", // Light purple
+		Highlight: "215", // Orange
+		Info:      "39",  // Bright blue
+		Text:      "252", // Light gray/white
+		Number:    "114", // Light green
+	},
+	// Blue and orange instead of green and red, distinguishable with the
+	// common forms of color blindness
+	"colorblind": {
+		Primary:   "75",  // Sky blue
+		Secondary: "214", // Orange
+		Success:   "33",  // Blue
+		Error:     "202", // Vermillion
+		Warning:   "226", // Yellow
+		Muted:     "245", // Gray
+		Accent:    "153", // Pale blue
+		Highlight: "220", // Gold
+		Info:      "39",  // Bright blue
+		Text:      "252", // Light gray/white
+		Number:    "117", // Light blue
+	},
+	// Darker colors that stay readable on light terminal backgrounds
+	"light": {
+		Primary:   "30",  // Teal
+		Secondary: "125", // Magenta
+		Success:   "28",  // Dark green
+		Error:     "160", // Dark red
+		Warning:   "136", // Dark yellow
+		Muted:     "244", // Gray
+		Accent:    "61",  // Slate blue
+		Highlight: "166", // Dark orange
+		Info:      "25",  // Dark blue
+		Text:      "236", // Dark gray
+		Number:    "28",  // Dark green
+	},
+}
+
 var (
-	// Color scheme
-	primaryColor   = lipgloss.Color("86")  // Cyan
-	secondaryColor = lipgloss.Color("212") // Pink
-	successColor   = lipgloss.Color("42")  // Green
-	errorColor     = lipgloss.Color("196") // Red
-	warningColor   = lipgloss.Color("220") // Yellow
-	mutedColor     = lipgloss.Color("241") // Gray
-	accentColor    = lipgloss.Color("147") // Light purple
-	highlightColor = lipgloss.Color("215") // Orange
-	infoBlueColor  = lipgloss.Color("39")  // Bright blue
+	// TUI styles, built by ApplyTheme
+	titleStyle          lipgloss.Style
+	selectedItemStyle   lipgloss.Style
+	normalItemStyle     lipgloss.Style
+	helpStyle           lipgloss.Style
+	statusRunningStyle  lipgloss.Style
+	statusStoppedStyle  lipgloss.Style
+	statusOtherStyle    lipgloss.Style
+	errorStyle          lipgloss.Style
+	infoStyle           lipgloss.Style
+	boxStyle            lipgloss.Style
+	paneStyle           lipgloss.Style
+	paletteStyle        lipgloss.Style
+	selectedBoxStyle    lipgloss.Style
+	labelStyle          lipgloss.Style
+	valueStyle          lipgloss.Style
+	sectionHeaderStyle  lipgloss.Style
+	tableHeaderStyle    lipgloss.Style
+	numberStyle         lipgloss.Style
+	arnStyle            lipgloss.Style
+	checkmarkStyle      lipgloss.Style
+	warningTextStyle    lipgloss.Style
+	mutedTextStyle      lipgloss.Style
+	mutedCheckboxStyle  lipgloss.Style
+	selectedCursorStyle lipgloss.Style
+	errorTextStyle      lipgloss.Style
+	changedRowStyle     lipgloss.Style
+	changedMarkerStyle  lipgloss.Style
+	sparklineStyle      lipgloss.Style
+	statusBarStyle      lipgloss.Style
+	statusBarKeyStyle   lipgloss.Style
+	statusBarWarnStyle  lipgloss.Style
+	statusBarSepStyle   lipgloss.Style
+	activeTabStyle      lipgloss.Style
+	inactiveTabStyle    lipgloss.Style
+
+	// CLI-specific exported styles
+	CLIPrimaryStyle   lipgloss.Style
+	CLISecondaryStyle lipgloss.Style
+	CLISuccessStyle   lipgloss.Style
+	CLIErrorStyle     lipgloss.Style
+	CLIWarningStyle   lipgloss.Style
+	CLIMutedStyle     lipgloss.Style
+	CLILabelStyle     lipgloss.Style
+	CLIValueStyle     lipgloss.Style
+	CLIHeaderStyle    lipgloss.Style
+	CLIHighlightStyle lipgloss.Style
+	CLINumberStyle    lipgloss.Style
+)
+
+func init() {
+	ApplyTheme(Themes["default"])
+}
 
+// ApplyTheme rebuilds the TUI styles and the exported CLI styles from a theme
+func ApplyTheme(t Theme) {
 	// Common styles
 	titleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(primaryColor).
-			MarginBottom(1)
+		Bold(true).
+		Foreground(t.Primary).
+		MarginBottom(1)
 
 	selectedItemStyle = lipgloss.NewStyle().
-				Foreground(secondaryColor).
-				Bold(true)
+		Foreground(t.Secondary).
+		Bold(true)
 
 	normalItemStyle = lipgloss.NewStyle()
 
 	helpStyle = lipgloss.NewStyle().
-			Foreground(mutedColor).
-			MarginTop(1)
+		Foreground(t.Muted).
+		MarginTop(1)
 
 	statusRunningStyle = lipgloss.NewStyle().
-				Foreground(successColor).
-				Bold(true)
+		Foreground(t.Success).
+		Bold(true)
 
 	statusStoppedStyle = lipgloss.NewStyle().
-				Foreground(errorColor).
-				Bold(true)
+		Foreground(t.Error).
+		Bold(true)
 
 	statusOtherStyle = lipgloss.NewStyle().
-				Foreground(warningColor).
-				Bold(true)
+		Foreground(t.Warning).
+		Bold(true)
 
 	errorStyle = lipgloss.NewStyle().
-			Foreground(errorColor).
-			Bold(true).
-			MarginTop(1)
+		Foreground(t.Error).
+		Bold(true).
+		MarginTop(1)
 
 	infoStyle = lipgloss.NewStyle().
-			Foreground(primaryColor).
-			MarginTop(1)
+		Foreground(t.Primary).
+		MarginTop(1)
 
 	boxStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(primaryColor).
-			Padding(1, 2)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2)
+
+	paneStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Muted).
+		Padding(0, 1)
+
+	paletteStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Secondary).
+		Padding(0, 1)
 
 	selectedBoxStyle = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(secondaryColor).
-				Padding(1, 2).
-				Bold(true)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Secondary).
+		Padding(1, 2).
+		Bold(true)
 
 	// Label and value styles for details view
 	labelStyle = lipgloss.NewStyle().
-			Foreground(accentColor).
-			Bold(true)
+		Foreground(t.Accent).
+		Bold(true)
 
 	valueStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("252")) // Light gray/white
+		Foreground(t.Text)
 
 	sectionHeaderStyle = lipgloss.NewStyle().
-				Foreground(highlightColor).
-				Bold(true).
-				MarginTop(1)
+		Foreground(t.Highlight).
+		Bold(true).
+		MarginTop(1)
 
 	tableHeaderStyle = lipgloss.NewStyle().
-				Foreground(infoBlueColor).
-				Bold(true)
+		Foreground(t.Info).
+		Bold(true)
 
 	numberStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("114")) // Light green
+		Foreground(t.Number)
 
 	arnStyle = lipgloss.NewStyle().
-			Foreground(mutedColor).
-			Italic(true)
+		Foreground(t.Muted).
+		Italic(true)
 
 	checkmarkStyle = lipgloss.NewStyle().
-			Foreground(successColor).
-			Bold(true)
+		Foreground(t.Success).
+		Bold(true)
 
 	// Additional TUI view styles
 	warningTextStyle = lipgloss.NewStyle().
-				Foreground(warningColor)
+		Foreground(t.Warning)
 
 	mutedTextStyle = lipgloss.NewStyle().
-			Foreground(mutedColor)
+		Foreground(t.Muted)
 
 	mutedCheckboxStyle = lipgloss.NewStyle().
-				Foreground(mutedColor)
+		Foreground(t.Muted)
 
 	selectedCursorStyle = lipgloss.NewStyle().
-				Foreground(secondaryColor).
-				Bold(true)
+		Foreground(t.Secondary).
+		Bold(true)
 
 	errorTextStyle = lipgloss.NewStyle().
-			Foreground(errorColor)
+		Foreground(t.Error)
+
+	changedRowStyle = lipgloss.NewStyle().
+		Foreground(t.Highlight).
+		Bold(true)
+
+	changedMarkerStyle = lipgloss.NewStyle().
+		Foreground(t.Highlight)
+
+	sparklineStyle = lipgloss.NewStyle().
+		Foreground(t.Info)
+
+	statusBarStyle = lipgloss.NewStyle().
+		Foreground(t.Muted)
+
+	statusBarKeyStyle = lipgloss.NewStyle().
+		Foreground(t.Primary).
+		Bold(true)
+
+	statusBarWarnStyle = lipgloss.NewStyle().
+		Foreground(t.Warning).
+		Bold(true)
+
+	statusBarSepStyle = lipgloss.NewStyle().
+		Foreground(t.Muted)
+
+	activeTabStyle = lipgloss.NewStyle().
+		Foreground(t.Primary).
+		Bold(true).
+		Underline(true)
+
+	inactiveTabStyle = lipgloss.NewStyle().
+		Foreground(t.Muted)
 
 	// CLI-specific exported styles
-	CLIPrimaryStyle   = lipgloss.NewStyle().Foreground(primaryColor).Bold(true)
-	CLISecondaryStyle = lipgloss.NewStyle().Foreground(secondaryColor)
-	CLISuccessStyle   = lipgloss.NewStyle().Foreground(successColor).Bold(true)
-	CLIErrorStyle     = lipgloss.NewStyle().Foreground(errorColor).Bold(true)
-	CLIWarningStyle   = lipgloss.NewStyle().Foreground(warningColor).Bold(true)
-	CLIMutedStyle     = lipgloss.NewStyle().Foreground(mutedColor)
-	CLILabelStyle     = lipgloss.NewStyle().Foreground(accentColor).Bold(true)
-	CLIValueStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
-	CLIHeaderStyle    = lipgloss.NewStyle().Foreground(infoBlueColor).Bold(true)
-	CLIHighlightStyle = lipgloss.NewStyle().Foreground(highlightColor).Bold(true)
-	CLINumberStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("114"))
-)
+	CLIPrimaryStyle = lipgloss.NewStyle().Foreground(t.Primary).Bold(true)
+	CLISecondaryStyle = lipgloss.NewStyle().Foreground(t.Secondary)
+	CLISuccessStyle = lipgloss.NewStyle().Foreground(t.Success).Bold(true)
+	CLIErrorStyle = lipgloss.NewStyle().Foreground(t.Error).Bold(true)
+	CLIWarningStyle = lipgloss.NewStyle().Foreground(t.Warning).Bold(true)
+	CLIMutedStyle = lipgloss.NewStyle().Foreground(t.Muted)
+	CLILabelStyle = lipgloss.NewStyle().Foreground(t.Accent).Bold(true)
+	CLIValueStyle = lipgloss.NewStyle().Foreground(t.Text)
+	CLIHeaderStyle = lipgloss.NewStyle().Foreground(t.Info).Bold(true)
+	CLIHighlightStyle = lipgloss.NewStyle().Foreground(t.Highlight).Bold(true)
+	CLINumberStyle = lipgloss.NewStyle().Foreground(t.Number)
+}
 
 // GetStatusStyle returns the appropriate style for a task status
 func GetStatusStyle(status string) lipgloss.Style {
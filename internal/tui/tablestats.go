@@ -0,0 +1,321 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/eljosho/dms-manager/pkg/dms"
+)
+
+// tableStatsSort is the column the table statistics view is sorted by
+type tableStatsSort int
+
+const (
+	sortNone tableStatsSort = iota
+	sortInserts
+	sortUpdates
+	sortDeletes
+	sortRows
+	sortState
+)
+
+// sortColumnCount is the number of values in the tableStatsSort cycle
+const sortColumnCount = 6
+
+func (s tableStatsSort) String() string {
+	switch s {
+	case sortInserts:
+		return "inserts"
+	case sortUpdates:
+		return "updates"
+	case sortDeletes:
+		return "deletes"
+	case sortRows:
+		return "rows"
+	case sortState:
+		return "state"
+	default:
+		return "none"
+	}
+}
+
+// tableKey identifies a table across refreshes of the same task
+func tableKey(s dms.TableStatistic) string {
+	return s.SchemaName + "." + s.TableName
+}
+
+// changedTables returns the keys of tables whose counters differ between two
+// successive polls. Tables that only appear in the new poll count as changed.
+func changedTables(prev, curr []dms.TableStatistic) map[string]bool {
+	old := make(map[string]dms.TableStatistic, len(prev))
+	for _, s := range prev {
+		old[tableKey(s)] = s
+	}
+
+	changed := make(map[string]bool)
+	for _, s := range curr {
+		p, ok := old[tableKey(s)]
+		if !ok || p.Inserts != s.Inserts || p.Updates != s.Updates || p.Deletes != s.Deletes ||
+			p.Ddls != s.Ddls || p.FullLoadRows != s.FullLoadRows || p.FullLoadErrorRows != s.FullLoadErrorRows ||
+			p.TableState != s.TableState || p.ValidationState != s.ValidationState {
+			changed[tableKey(s)] = true
+		}
+	}
+	return changed
+}
+
+// tableStatsFilter matches tables against a filter expression; see
+// parseFilterTerms for the syntax. "schema", "table" and "state" terms match
+// the respective field, any other term matches schema.table.
+type tableStatsFilter map[string][]string
+
+func parseTableStatsFilter(expr string) tableStatsFilter {
+	return tableStatsFilter(parseFilterTerms(expr, "schema", "table", "state"))
+}
+
+func (f tableStatsFilter) matches(s dms.TableStatistic) bool {
+	return containsAll(s.SchemaName, f["schema"]) &&
+		containsAll(s.TableName, f["table"]) &&
+		containsAll(s.ValidationState, f["state"]) &&
+		containsAll(tableKey(s), f[""])
+}
+
+// visibleTableStats returns the filtered and sorted table statistics for the
+// table statistics view. The underlying slice is left untouched.
+func (m Model) visibleTableStats() []dms.TableStatistic {
+	filter := parseTableStatsFilter(m.statsFilter)
+
+	rows := make([]dms.TableStatistic, 0, len(m.tableStats))
+	for _, s := range m.tableStats {
+		if filter.matches(s) {
+			rows = append(rows, s)
+		}
+	}
+
+	if m.statsSort == sortNone {
+		return rows
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if m.statsSort == sortState {
+			if m.statsSortAsc {
+				return strings.ToLower(a.ValidationState) < strings.ToLower(b.ValidationState)
+			}
+			return strings.ToLower(a.ValidationState) > strings.ToLower(b.ValidationState)
+		}
+		if m.statsSortAsc {
+			return sortValue(a, m.statsSort) < sortValue(b, m.statsSort)
+		}
+		return sortValue(a, m.statsSort) > sortValue(b, m.statsSort)
+	})
+
+	return rows
+}
+
+func sortValue(s dms.TableStatistic, col tableStatsSort) int64 {
+	switch col {
+	case sortInserts:
+		return s.Inserts
+	case sortUpdates:
+		return s.Updates
+	case sortDeletes:
+		return s.Deletes
+	case sortRows:
+		return s.FullLoadRows
+	default:
+		return 0
+	}
+}
+
+// cursorTable returns the table under the cursor of the table statistics
+// view, clamping the cursor to the visible rows
+func (m *Model) cursorTable() (dms.TableStatistic, bool) {
+	rows := m.visibleTableStats()
+	if len(rows) == 0 {
+		return dms.TableStatistic{}, false
+	}
+	m.statsCursor = min(max(m.statsCursor, 0), len(rows)-1)
+	return rows[m.statsCursor], true
+}
+
+// moveStatsCursor moves the row cursor of the table statistics view and
+// scrolls the viewport to keep it visible. The rows start at the first line
+// of the viewport.
+func (m *Model) moveStatsCursor(act action) {
+	rows := m.visibleTableStats()
+	if len(rows) == 0 {
+		return
+	}
+	m.syncViewport()
+	page := max(m.viewport.Height, 1)
+
+	switch act {
+	case actionLineUp:
+		m.statsCursor--
+	case actionLineDown:
+		m.statsCursor++
+	case actionPageUp:
+		m.statsCursor -= page
+	case actionPageDown:
+		m.statsCursor += page
+	case actionTop:
+		m.statsCursor = 0
+	case actionBottom:
+		m.statsCursor = len(rows) - 1
+	}
+	m.statsCursor = min(max(m.statsCursor, 0), len(rows)-1)
+
+	if m.statsCursor < m.viewport.YOffset {
+		m.viewport.SetYOffset(m.statsCursor)
+	} else if m.statsCursor >= m.viewport.YOffset+page {
+		m.viewport.SetYOffset(m.statsCursor - page + 1)
+	}
+	if act == actionBottom {
+		// Bring the totals below the last row into view as well
+		m.viewport.GotoBottom()
+	}
+}
+
+// toggleStatsSelection selects or deselects the table under the cursor for
+// reloading
+func (m *Model) toggleStatsSelection() {
+	s, ok := m.cursorTable()
+	if !ok {
+		return
+	}
+	if m.statsSelected == nil {
+		m.statsSelected = make(map[string]bool)
+	}
+	if m.statsSelected[tableKey(s)] {
+		delete(m.statsSelected, tableKey(s))
+	} else {
+		m.statsSelected[tableKey(s)] = true
+	}
+}
+
+// reloadTargets returns the selected tables, or the table under the cursor if
+// none are selected
+func (m *Model) reloadTargets() []dms.TableRef {
+	var tables []dms.TableRef
+	for _, s := range m.tableStats {
+		if m.statsSelected[tableKey(s)] {
+			tables = append(tables, dms.TableRefOf(s))
+		}
+	}
+	if len(tables) > 0 {
+		return tables
+	}
+	if s, ok := m.cursorTable(); ok {
+		return []dms.TableRef{dms.TableRefOf(s)}
+	}
+	return nil
+}
+
+// tableReload is a reload of tables of a task
+type tableReload struct {
+	arn    string
+	task   string
+	tables []dms.TableRef
+	mode   dms.ReloadMode
+}
+
+// reloadTables reloads or revalidates the target tables of the task whose
+// statistics are shown. Reloads truncate the target tables, so they wait for
+// confirmation.
+func (m Model) reloadTables(mode dms.ReloadMode) (tea.Model, tea.Cmd) {
+	if m.readOnly {
+		m.operationMsg = "Read-only mode: task operations are disabled"
+		return m, nil
+	}
+	if m.state != viewTableStats {
+		m.operationMsg = "Open the table statistics ([T]) to pick the tables to reload"
+		return m, nil
+	}
+	tables := m.reloadTargets()
+	if len(tables) == 0 || m.tableStatsARN == "" {
+		return m, nil
+	}
+	reload := tableReload{arn: m.tableStatsARN, task: m.tableStatsARN, tables: tables, mode: mode}
+	if task, ok := m.detailsTask(); ok && task.ARN == reload.arn {
+		reload.task = task.Name
+	}
+	if mode == dms.ReloadData {
+		m.reloadConfirm = &reload
+		return m, nil
+	}
+	return m.runTableReload(reload)
+}
+
+// runTableReload sends a table reload
+func (m Model) runTableReload(r tableReload) (tea.Model, tea.Cmd) {
+	m.operationMsg = fmt.Sprintf("Requesting %s for %d table(s)...", r.mode, len(r.tables))
+	return m, ReloadTablesCmd(m.clientFor(r.arn), r.arn, r.tables, r.mode)
+}
+
+func (m Model) handleReloadConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if key == "ctrl+c" {
+		return m, tea.Quit
+	}
+
+	reload := *m.reloadConfirm
+	m.reloadConfirm = nil
+	if key != "y" {
+		m.operationMsg = "Table reload cancelled"
+		return m, nil
+	}
+	return m.runTableReload(reload)
+}
+
+// renderReloadConfirm asks to confirm a table reload below the current view
+func (m Model) renderReloadConfirm() string {
+	r := m.reloadConfirm
+
+	var sb strings.Builder
+	sb.WriteString(sectionHeaderStyle.UnsetMarginTop().Render(fmt.Sprintf("Reload %d table(s) of %s?", len(r.tables), r.task)))
+	sb.WriteString("\n")
+	sb.WriteString(warningTextStyle.Render("Their target data is truncated and loaded again from the source."))
+	sb.WriteString("\n")
+	sb.WriteString(helpStyle.Render("Press [y] to reload • any other key cancels"))
+
+	return paletteStyle.Render(sb.String())
+}
+
+// sumTableStats totals the counters of the given tables
+func sumTableStats(stats []dms.TableStatistic) dms.TableStatistic {
+	var total dms.TableStatistic
+	for _, s := range stats {
+		total.Inserts += s.Inserts
+		total.Updates += s.Updates
+		total.Deletes += s.Deletes
+		total.Ddls += s.Ddls
+		total.FullLoadRows += s.FullLoadRows
+	}
+	return total
+}
+
+// scrollString returns the window of s starting at offset, padded or cut to
+// width. A leading or trailing ellipsis marks text hidden by the window.
+func scrollString(s string, offset, width int) string {
+	r := []rune(s)
+	if offset > len(r) {
+		offset = len(r)
+	}
+	r = r[offset:]
+
+	if len(r) > width {
+		r = append(r[:width-1], '…')
+	}
+	if offset > 0 && len(r) > 0 {
+		r[0] = '…'
+	}
+
+	out := string(r)
+	if pad := width - len(r); pad > 0 {
+		out += strings.Repeat(" ", pad)
+	}
+	return out
+}
@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eljosho/dms-manager/internal/config"
+	"github.com/muesli/termenv"
+)
+
+// Configure applies the theme, color overrides, notification settings and
+// key bindings from the config file. Colors are disabled entirely when
+// noColor is set or the NO_COLOR environment variable is non-empty.
+func Configure(cfg *config.Config, noColor bool) error {
+	if noColor || os.Getenv("NO_COLOR") != "" {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+
+	name := cfg.Theme
+	if name == "" {
+		name = "default"
+	}
+	theme, ok := Themes[name]
+	if !ok {
+		return fmt.Errorf("unknown theme %q (available: %s)", name, strings.Join(themeNames(), ", "))
+	}
+
+	for key, value := range cfg.Colors {
+		c := themeColor(&theme, key)
+		if c == nil {
+			return fmt.Errorf("unknown color %q in config (available: primary, secondary, success, error, warning, muted, accent, highlight, info, text, number)", key)
+		}
+		*c = lipgloss.Color(value)
+	}
+	ApplyTheme(theme)
+
+	if err := validateNotify(cfg.Notify); err != nil {
+		return err
+	}
+	notifySettings = cfg.Notify
+
+	return applyKeymap(cfg.Keys)
+}
+
+// themeColor returns the theme field for a color name used in the config file
+func themeColor(t *Theme, name string) *lipgloss.Color {
+	switch strings.ToLower(name) {
+	case "primary":
+		return &t.Primary
+	case "secondary":
+		return &t.Secondary
+	case "success":
+		return &t.Success
+	case "error":
+		return &t.Error
+	case "warning":
+		return &t.Warning
+	case "muted":
+		return &t.Muted
+	case "accent":
+		return &t.Accent
+	case "highlight":
+		return &t.Highlight
+	case "info":
+		return &t.Info
+	case "text":
+		return &t.Text
+	case "number":
+		return &t.Number
+	default:
+		return nil
+	}
+}
+
+func themeNames() []string {
+	names := make([]string, 0, len(Themes))
+	for name := range Themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,265 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/eljosho/dms-manager/internal/config"
+	"github.com/eljosho/dms-manager/pkg/dms"
+)
+
+const (
+	// highlightDuration is how long a changed task row stays highlighted
+	highlightDuration = 10 * time.Second
+
+	// transitionLogSize caps the number of remembered transitions
+	transitionLogSize = 200
+
+	// transitionPaneRows is the number of transitions shown under the list
+	transitionPaneRows = 5
+
+	// fullLoadComplete is the pseudo status of a task finishing its full load
+	fullLoadComplete = "full-load-complete"
+)
+
+// defaultNotifyTransitions apply when notifications are enabled without
+// listing transitions
+var defaultNotifyTransitions = []string{"*->failed", fullLoadComplete}
+
+// notifySettings holds the notification settings from the config file
+var notifySettings config.Notify
+
+// transition is a change of a task observed between two refreshes
+type transition struct {
+	at      time.Time
+	arn     string
+	name    string
+	context string
+	from    string
+	to      string
+}
+
+func (t transition) String() string {
+	if t.to == fullLoadComplete {
+		return fmt.Sprintf("%s: full load complete", t.name)
+	}
+	return fmt.Sprintf("%s: %s → %s", t.name, t.from, t.to)
+}
+
+// diffTasks returns the transitions between two task lists: status changes
+// and full loads reaching 100%. Tasks that appear or disappear are ignored.
+func diffTasks(prev, curr []dms.Task, contexts map[string]string, at time.Time) []transition {
+	old := make(map[string]dms.Task, len(prev))
+	for _, t := range prev {
+		old[t.ARN] = t
+	}
+
+	var changes []transition
+	for _, t := range curr {
+		p, ok := old[t.ARN]
+		if !ok {
+			continue
+		}
+		if p.Status != t.Status {
+			changes = append(changes, transition{at: at, arn: t.ARN, name: t.Name, context: contexts[t.ARN], from: p.Status, to: t.Status})
+		}
+		if fullLoadPercent(p) < 100 && fullLoadPercent(t) == 100 {
+			changes = append(changes, transition{at: at, arn: t.ARN, name: t.Name, context: contexts[t.ARN], from: p.Status, to: fullLoadComplete})
+		}
+	}
+	return changes
+}
+
+func fullLoadPercent(t dms.Task) int32 {
+	if t.ReplicationTaskStats == nil {
+		return 0
+	}
+	return t.ReplicationTaskStats.FullLoadProgressPercent
+}
+
+// validateNotify checks the notification settings from the config file
+func validateNotify(n config.Notify) error {
+	switch n.Desktop {
+	case "", "osc9", "osc777":
+	default:
+		return fmt.Errorf("unknown desktop notification method %q (available: osc9, osc777)", n.Desktop)
+	}
+	for _, pattern := range n.Transitions {
+		if pattern == fullLoadComplete {
+			continue
+		}
+		if _, _, ok := strings.Cut(pattern, "->"); !ok {
+			return fmt.Errorf("invalid transition %q: expected from->to or %s", pattern, fullLoadComplete)
+		}
+	}
+	return nil
+}
+
+// shouldNotify reports whether a transition matches the configured patterns
+func shouldNotify(t transition) bool {
+	if !notifySettings.Bell && notifySettings.Desktop == "" {
+		return false
+	}
+
+	patterns := notifySettings.Transitions
+	if len(patterns) == 0 {
+		patterns = defaultNotifyTransitions
+	}
+
+	for _, pattern := range patterns {
+		if pattern == fullLoadComplete {
+			if t.to == fullLoadComplete {
+				return true
+			}
+			continue
+		}
+		if t.to == fullLoadComplete {
+			continue
+		}
+		from, to, _ := strings.Cut(strings.ToLower(pattern), "->")
+		if matchStatus(strings.TrimSpace(from), t.from) && matchStatus(strings.TrimSpace(to), t.to) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchStatus(pattern, status string) bool {
+	return pattern == "*" || pattern == strings.ToLower(status)
+}
+
+// NotifyCmd rings the bell and sends a desktop notification as configured.
+// The escape sequences go to stderr so they don't interleave with frames
+// written by the renderer.
+func NotifyCmd(changes []transition) tea.Cmd {
+	return func() tea.Msg {
+		var sb strings.Builder
+		if notifySettings.Bell {
+			sb.WriteString("\a")
+		}
+		for _, t := range changes {
+			body := sanitizeOSC(t.String())
+			switch notifySettings.Desktop {
+			case "osc9":
+				sb.WriteString("\x1b]9;dms-manager: " + body + "\x07")
+			case "osc777":
+				sb.WriteString("\x1b]777;notify;dms-manager;" + body + "\x07")
+			}
+		}
+		os.Stderr.WriteString(sb.String())
+		return nil
+	}
+}
+
+// sanitizeOSC strips characters that would terminate an OSC sequence early
+func sanitizeOSC(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == ';' {
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+// recordTransitions diffs a refresh against the previous task list, marks the
+// changed rows and returns a notification command for matching transitions
+func (m *Model) recordTransitions(prev []dms.Task) tea.Cmd {
+	if prev == nil {
+		return nil
+	}
+
+	changes := diffTasks(prev, m.allTasks, m.taskContexts, time.Now())
+	if len(changes) == 0 {
+		return nil
+	}
+
+	var notify []transition
+	for _, t := range changes {
+		m.changedTasks[t.arn] = t.at
+		if shouldNotify(t) {
+			notify = append(notify, t)
+		}
+	}
+
+	m.transitions = append(m.transitions, changes...)
+	if len(m.transitions) > transitionLogSize {
+		m.transitions = m.transitions[len(m.transitions)-transitionLogSize:]
+	}
+
+	if len(notify) == 0 {
+		return nil
+	}
+	return NotifyCmd(notify)
+}
+
+// isRecentlyChanged reports whether a task row should be highlighted
+func (m Model) isRecentlyChanged(arn string) bool {
+	at, ok := m.changedTasks[arn]
+	return ok && time.Since(at) < highlightDuration
+}
+
+// renderTransitionLine renders one transition log entry
+func (m Model) renderTransitionLine(t transition) string {
+	to := t.to
+	style := GetStatusStyle(strings.ToLower(t.to))
+	if t.to == fullLoadComplete {
+		to = "full load complete"
+		style = statusRunningStyle
+	}
+
+	name := t.name
+	if t.context != "" && m.merged {
+		name = t.context + " " + name
+	}
+	return fmt.Sprintf("%s %s %s → %s",
+		mutedTextStyle.Render(t.at.Format("15:04:05")),
+		valueStyle.Render(name),
+		mutedTextStyle.Render(t.from),
+		style.Render(to))
+}
+
+// renderTransitionPane renders the latest transitions under the task list
+func (m Model) renderTransitionPane() string {
+	if len(m.transitions) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(sectionHeaderStyle.Render("Recent transitions"))
+	sb.WriteString("\n")
+	start := max(len(m.transitions)-transitionPaneRows, 0)
+	for i := len(m.transitions) - 1; i >= start; i-- {
+		sb.WriteString("  " + m.renderTransitionLine(m.transitions[i]) + "\n")
+	}
+	return sb.String()
+}
+
+// renderTransitions renders the full transitions log, newest first
+func (m Model) renderTransitions() string {
+	var sb strings.Builder
+
+	sb.WriteString(titleStyle.Render("Task Transitions"))
+	sb.WriteString("\n")
+
+	if len(m.transitions) == 0 {
+		sb.WriteString(mutedTextStyle.Render("No transitions since the TUI started."))
+		sb.WriteString("\n")
+	}
+
+	// Keep the newest entries on screen
+	rows := len(m.transitions)
+	if visible := m.height - 8; visible > 0 && rows > visible {
+		rows = visible
+	}
+	for i := len(m.transitions) - 1; i >= len(m.transitions)-rows; i-- {
+		sb.WriteString("  " + m.renderTransitionLine(m.transitions[i]) + "\n")
+	}
+
+	sb.WriteString(m.renderBindingsHelp(viewTransitions))
+	sb.WriteString(m.renderStatusBar())
+
+	return sb.String()
+}
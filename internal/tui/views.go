@@ -3,12 +3,29 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/x/ansi"
 	"github.com/eljosho/dms-manager/pkg/dms"
 )
 
-// View renders the current view
+// View renders the current view, with the command palette, copy menu or
+// table reload confirmation below it when open
 func (m Model) View() string {
+	view := m.renderView()
+	if m.paletteActive {
+		view += "\n" + m.renderPalette() + "\n"
+	}
+	if m.copyMenu {
+		view += "\n" + m.renderCopyMenu() + "\n"
+	}
+	if m.reloadConfirm != nil {
+		view += "\n" + m.renderReloadConfirm() + "\n"
+	}
+	return view
+}
+
+func (m Model) renderView() string {
 	switch m.state {
 	case viewLoading:
 		return m.renderLoading()
@@ -16,10 +33,18 @@ func (m Model) View() string {
 		return m.renderError()
 	case viewTaskList:
 		return m.renderTaskList()
-	case viewTaskDetails:
-		return m.renderTaskDetails()
-	case viewTableStats:
-		return m.renderTableStats()
+	case viewTaskDetails, viewTableStats, viewMappings:
+		return m.renderScrollView()
+	case viewContextPicker:
+		return m.renderContextPicker()
+	case viewHelp:
+		return m.renderHelpOverlay()
+	case viewTransitions:
+		return m.renderTransitions()
+	case viewCreateTask:
+		return m.renderWizard()
+	case viewInstances:
+		return m.renderInstances()
 	default:
 		return "Unknown state"
 	}
@@ -34,77 +59,182 @@ func (m Model) renderError() string {
 
 	sb.WriteString(titleStyle.Render("Error"))
 	sb.WriteString("\n\n")
-	sb.WriteString(errorStyle.Render(fmt.Sprintf("Failed to load tasks: %v", m.err)))
-	sb.WriteString("\n\n")
-	sb.WriteString(helpStyle.Render("Press 'r' to retry or 'q' to quit"))
+	sb.WriteString(errorStyle.Render(fmt.Sprintf("Failed to load: %v", m.err)))
+	sb.WriteString("\n")
+	if dms.IsExpiredCredentials(m.err) {
+		sb.WriteString(warningTextStyle.Render(dms.LoginHint(m.client.GetProfile())))
+		sb.WriteString("\n")
+	}
+	sb.WriteString(m.renderBindingsHelp(viewError))
 	sb.WriteString("\n")
 
 	return sb.String()
 }
 
+// renderRefreshStatus renders a line describing a failing background
+// refresh, or nothing while refreshes succeed
+func (m Model) renderRefreshStatus() string {
+	if m.refreshErr == nil {
+		return ""
+	}
+
+	status := fmt.Sprintf("⚠ Refresh failed (%d in a row): %v", m.scheduler.Failures(), m.refreshErr)
+	if dms.IsExpiredCredentials(m.refreshErr) {
+		status = fmt.Sprintf("⚠ %s (%d failed refreshes)", dms.LoginHint(m.client.GetProfile()), m.scheduler.Failures())
+	}
+	if m.autoRefresh {
+		wait := time.Until(m.nextRefresh).Round(time.Second)
+		status += fmt.Sprintf(" • retrying in %s", max(wait, 0))
+	} else {
+		status += fmt.Sprintf(" • press %s to retry", keyLabelFor(m.state, actionRefresh))
+	}
+
+	width := m.width
+	if width == 0 {
+		width = 120
+	}
+	return "\n" + errorTextStyle.Render(truncateString(status, width)) + "\n"
+}
+
 func (m Model) renderTaskList() string {
+	if m.isSplitView() {
+		return m.renderSplitView()
+	}
+
 	var sb strings.Builder
 
-	// Header
+	sb.WriteString(m.renderTaskListHeader())
+	sb.WriteString(m.renderTaskRows())
+	sb.WriteString(m.renderTransitionPane())
+	sb.WriteString("\n")
+	sb.WriteString(m.renderRefreshStatus())
+	sb.WriteString(m.renderHelp())
+	sb.WriteString(m.renderStatusBar())
+
+	return sb.String()
+}
+
+// renderTaskListHeader renders the title and any pending operation message
+func (m Model) renderTaskListHeader() string {
+	var sb strings.Builder
+
+	sb.WriteString(m.renderTabs())
+
 	title := fmt.Sprintf("AWS DMS Tasks - %s", m.client.GetRegion())
 	if m.client.GetProfile() != "" {
 		title += fmt.Sprintf(" (Profile: %s)", m.client.GetProfile())
 	}
+	if m.merged {
+		title = fmt.Sprintf("AWS DMS Tasks - %d contexts (merged)", len(m.contextClients()))
+	}
 	sb.WriteString(titleStyle.Render(title))
 	sb.WriteString("\n")
 
+	if m.taskFilter != "" {
+		sb.WriteString(fmt.Sprintf("%s %s %s\n", labelStyle.Render("Filter:"), valueStyle.Render(m.taskFilter),
+			mutedTextStyle.Render(fmt.Sprintf("(%d of %d tasks)", len(m.tasks), len(m.allTasks)))))
+	}
+
 	// Operation message
 	if m.operationMsg != "" {
 		sb.WriteString(infoStyle.Render(m.operationMsg))
 		sb.WriteString("\n\n")
 	}
 
-	// Task list
+	return sb.String()
+}
+
+// renderTaskRows renders one line per task with cursor and selection markers
+func (m Model) renderTaskRows() string {
+	var sb strings.Builder
+
 	if len(m.tasks) == 0 {
 		sb.WriteString(warningTextStyle.Render("No tasks found."))
 		sb.WriteString("\n")
-	} else {
-		for i, task := range m.tasks {
-			checkbox := mutedCheckboxStyle.Render("[ ]")
-			if m.selected[i] {
-				checkbox = checkmarkStyle.Render("[✓]")
-			}
+		return sb.String()
+	}
 
-			cursor := "  "
-			nameStyle := normalItemStyle
-			if i == m.cursor {
-				cursor = selectedCursorStyle.Render("→ ")
-				nameStyle = selectedItemStyle
-			}
+	// Context column for the merged view
+	contextWidth := 0
+	if m.merged {
+		for _, name := range m.taskContexts {
+			contextWidth = max(contextWidth, len(name))
+		}
+	}
 
-			statusStyle := GetStatusStyle(strings.ToLower(task.Status))
-			status := statusStyle.Render(task.Status)
-			migrationType := mutedTextStyle.Render(fmt.Sprintf("(%s)", task.MigrationType))
+	for i, task := range m.tasks {
+		checkbox := mutedCheckboxStyle.Render("[ ]")
+		if m.selected[task.ARN] {
+			checkbox = checkmarkStyle.Render("[✓]")
+		}
 
-			line := fmt.Sprintf("%s%s %s - %s %s", cursor, checkbox, nameStyle.Render(task.Name), status, migrationType)
-			sb.WriteString(line)
-			sb.WriteString("\n")
+		cursor := "  "
+		nameStyle := normalItemStyle
+		if m.isRecentlyChanged(task.ARN) {
+			cursor = changedMarkerStyle.Render("● ")
+			nameStyle = changedRowStyle
+		}
+		if i == m.cursor {
+			cursor = selectedCursorStyle.Render("→ ")
+			nameStyle = selectedItemStyle
 		}
-	}
 
-	sb.WriteString("\n")
-	sb.WriteString(m.renderHelp())
+		statusStyle := GetStatusStyle(strings.ToLower(task.Status))
+		status := statusStyle.Render(task.Status)
+		migrationType := mutedTextStyle.Render(fmt.Sprintf("(%s)", task.MigrationType))
+
+		contextColumn := ""
+		if m.merged {
+			contextColumn = mutedTextStyle.Render(fmt.Sprintf("%-*s", contextWidth, m.taskContexts[task.ARN])) + " "
+		}
+
+		line := fmt.Sprintf("%s%s %s%s - %s %s", cursor, checkbox, contextColumn, nameStyle.Render(task.Name), status, migrationType)
+		if trend := m.renderTaskThroughput(task.ARN); trend != "" {
+			line += " " + trend
+		}
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
 
 	return sb.String()
 }
 
-func (m Model) renderTaskDetails() string {
-	if m.detailsTaskIdx >= len(m.tasks) {
-		return "Invalid task index"
+// taskDetailsParts splits the details view for the viewport
+func (m Model) taskDetailsParts() (header, body, help string) {
+	task, ok := m.detailsTask()
+	if !ok {
+		return "Task not found", "", ""
+	}
+	header = titleStyle.Render("Task Details")
+	body = m.renderTaskDetailsBody(task, m.screenWidth())
+	return header, body, m.renderBindingsHelp(viewTaskDetails)
+}
+
+// renderTaskThroughput renders a sparkline and the latest rate for a task,
+// flagging tasks whose throughput dropped to zero
+func (m Model) renderTaskThroughput(arn string) string {
+	w := m.throughput.Task(arn)
+	if w == nil {
+		return ""
+	}
+	rate, ok := w.Latest()
+	if !ok {
+		return ""
 	}
 
-	task := m.tasks[m.detailsTaskIdx]
+	out := sparklineStyle.Render(dms.Sparkline(w.Totals(), sparklineWidth)) + " " + numberStyle.Render(dms.FormatRate(rate.Total()))
+	if w.Stalled() {
+		out += " " + statusOtherStyle.Render("⚠ stalled")
+	}
+	return out
+}
 
+// renderTaskDetailsBody renders the task fields shared by the full-screen
+// details view and the split-view details pane, wrapping the failure
+// message to width
+func (m Model) renderTaskDetailsBody(task dms.Task, width int) string {
 	var sb strings.Builder
 
-	sb.WriteString(titleStyle.Render("Task Details"))
-	sb.WriteString("\n\n")
-
 	// Basic info with colored labels
 	sb.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Name:"), valueStyle.Render(task.Name)))
 	sb.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Status:"), GetStatusStyle(strings.ToLower(task.Status)).Render(task.Status)))
@@ -115,17 +245,36 @@ func (m Model) renderTaskDetails() string {
 	// Endpoints section
 	sb.WriteString(sectionHeaderStyle.Render("Endpoints:"))
 	sb.WriteString("\n")
-	sb.WriteString(fmt.Sprintf("  %s %s\n", labelStyle.Render("Source:"), arnStyle.Render(task.SourceEndpointARN)))
-	sb.WriteString(fmt.Sprintf("  %s %s\n", labelStyle.Render("Target:"), arnStyle.Render(task.TargetEndpointARN)))
-	sb.WriteString(fmt.Sprintf("  %s %s\n", labelStyle.Render("Instance:"), arnStyle.Render(task.ReplicationInstanceARN)))
+	sb.WriteString(fmt.Sprintf("  %s %s\n", labelStyle.Render("Source:"), m.renderEndpoint(task.SourceEndpointARN)))
+	sb.WriteString(fmt.Sprintf("  %s %s\n", labelStyle.Render("Target:"), m.renderEndpoint(task.TargetEndpointARN)))
+	sb.WriteString(fmt.Sprintf("  %s %s\n", labelStyle.Render("Instance:"), m.renderInstanceName(task.ReplicationInstanceARN)))
+	if task.IsMoving() {
+		sb.WriteString(fmt.Sprintf("  %s %s %s\n", labelStyle.Render("Moving To:"),
+			m.renderInstanceName(task.TargetReplicationInstanceARN), statusOtherStyle.Render("(pending)")))
+	}
 	sb.WriteString("\n")
 
 	// Timestamps
+	now := time.Now()
 	if task.CreatedAt != nil {
 		sb.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Created:"), valueStyle.Render(task.CreatedAt.Format("2006-01-02 15:04:05"))))
 	}
 	if task.StartedAt != nil {
-		sb.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Started:"), valueStyle.Render(task.StartedAt.Format("2006-01-02 15:04:05"))))
+		sb.WriteString(fmt.Sprintf("%s %s%s\n", labelStyle.Render("Started:"), valueStyle.Render(task.StartedAt.Format("2006-01-02 15:04:05")), renderRunDuration(task, now)))
+	}
+	if task.StoppedAt != nil {
+		sb.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Stopped:"), valueStyle.Render(task.StoppedAt.Format("2006-01-02 15:04:05"))))
+	}
+	if stats := task.ReplicationTaskStats; stats != nil {
+		if stats.FreshStartDate != nil {
+			sb.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Fresh Start:"), valueStyle.Render(stats.FreshStartDate.Format("2006-01-02 15:04:05"))))
+		}
+		if stats.FullLoadStartDate != nil {
+			sb.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Full Load:"), renderFullLoadWindow(*stats, now)))
+		}
+	}
+	if task.StopReason != "" {
+		sb.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Stop Reason:"), statusOtherStyle.Render(task.StopReason)))
 	}
 
 	// Statistics
@@ -152,130 +301,313 @@ func (m Model) renderTaskDetails() string {
 		if stats.ElapsedTimeMillis > 0 {
 			sb.WriteString(fmt.Sprintf("  %s %s\n", labelStyle.Render("Elapsed:"), valueStyle.Render(dms.FormatElapsedTime(stats.ElapsedTimeMillis))))
 		}
-
-		if stats.StopReason != "" {
-			sb.WriteString(fmt.Sprintf("  %s %s\n", labelStyle.Render("Stop Reason:"), statusOtherStyle.Render(stats.StopReason)))
-		}
 	}
 
-	// Extended stats - Table Mappings
-	if m.showExtendedStats && task.TableMappings != "" {
-		sb.WriteString("\n")
-		sb.WriteString(sectionHeaderStyle.Render("Table Mappings:"))
-		sb.WriteString("\n")
-		sb.WriteString(mutedTextStyle.Render(task.TableMappings))
+	// Table mappings and settings summaries; the full rules are in the
+	// mappings view
+	if task.TableMappings != "" || task.Settings != "" {
 		sb.WriteString("\n")
 	}
+	if task.TableMappings != "" {
+		sb.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Table Mappings:"), m.mappingsSummary(task)))
+	}
+	if task.Settings != "" {
+		sb.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Settings:"), renderSettingsSummary(task.Settings)))
+	}
+	if task.TaskData != "" {
+		sb.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Task Data:"), mutedTextStyle.Render(ansi.Truncate(task.TaskData, max(width-11, 10), "…"))))
+	}
 
 	// Error info
 	if task.LastFailureMessage != "" {
 		sb.WriteString("\n")
 		sb.WriteString(errorStyle.Render("Last Failure:"))
 		sb.WriteString("\n")
-		sb.WriteString(errorTextStyle.Render(task.LastFailureMessage))
+		sb.WriteString(errorTextStyle.Render(ansi.Wrap(task.LastFailureMessage, width, "")))
 		sb.WriteString("\n")
 	}
 
-	sb.WriteString("\n")
-
-	// Build help string parts
-	var parts []string
+	return sb.String()
+}
 
-	if task.TableMappings != "" {
-		extendedStatus := mutedTextStyle.Render("off")
-		if m.showExtendedStats {
-			extendedStatus = statusRunningStyle.Render("on")
-		}
-		parts = append(parts, fmt.Sprintf("[t] extended stats: %s", extendedStatus))
+// renderRunDuration renders how long a task ran or has been running, e.g.
+// " (running for 2h 5m)", or nothing if that is unknown
+func renderRunDuration(task dms.Task, now time.Time) string {
+	d, ok := task.RunDuration(now)
+	if !ok {
+		return ""
 	}
+	if task.StoppedAt != nil && task.StoppedAt.After(*task.StartedAt) {
+		return mutedTextStyle.Render(" (ran " + dms.FormatDuration(d) + ")")
+	}
+	if strings.EqualFold(task.Status, "running") {
+		return mutedTextStyle.Render(" (running for " + dms.FormatDuration(d) + ")")
+	}
+	return ""
+}
 
-	parts = append(parts, "[T] table stats")
-	parts = append(parts, "[ESC] back")
-
-	helpText := fmt.Sprintf("Press %s", strings.Join(parts, " • "))
-
-	sb.WriteString(helpStyle.Render(helpText))
-	sb.WriteString("\n")
-
-	return sb.String()
+// renderFullLoadWindow renders when the full load started and finished and
+// how long it took, or how long it has been running
+func renderFullLoadWindow(stats dms.TaskStats, now time.Time) string {
+	out := valueStyle.Render(stats.FullLoadStartDate.Format("2006-01-02 15:04:05"))
+	d, _ := stats.FullLoadDuration(now)
+	if stats.FullLoadFinishDate == nil {
+		return out + mutedTextStyle.Render(" (running for "+dms.FormatDuration(d)+")")
+	}
+	finish := stats.FullLoadFinishDate.Format("2006-01-02 15:04:05")
+	if stats.FullLoadFinishDate.YearDay() == stats.FullLoadStartDate.YearDay() && stats.FullLoadFinishDate.Year() == stats.FullLoadStartDate.Year() {
+		finish = stats.FullLoadFinishDate.Format("15:04:05")
+	}
+	return out + " → " + valueStyle.Render(finish) +
+		mutedTextStyle.Render(" (took "+dms.FormatDuration(d)+")")
 }
 
-func (m Model) renderTableStats() string {
-	if m.detailsTaskIdx >= len(m.tasks) {
-		return "Invalid task index"
+// renderSettingsSummary renders the notable task settings on one line
+func renderSettingsSummary(settings string) string {
+	summary, err := dms.SettingsSummary(settings)
+	if err != nil {
+		return errorTextStyle.Render(err.Error())
+	}
+	if len(summary) == 0 {
+		return mutedTextStyle.Render("DMS defaults")
 	}
+	return valueStyle.Render(strings.Join(summary, ", "))
+}
 
-	task := m.tasks[m.detailsTaskIdx]
+// tableStatsParts splits the table statistics view for the viewport: the
+// column headers stay above the scrolled rows
+func (m Model) tableStatsParts() (header, body, help string) {
+	task, ok := m.detailsTask()
+	if !ok {
+		return "Task not found", "", ""
+	}
 
-	var sb strings.Builder
+	var sb, rb strings.Builder
 
 	sb.WriteString(titleStyle.Render(fmt.Sprintf("Table Statistics - %s", task.Name)))
-	sb.WriteString("\n\n")
+	sb.WriteString("\n")
 
-	if len(m.tableStats) == 0 {
-		sb.WriteString(warningTextStyle.Render("No table statistics available."))
+	rows := m.visibleTableStats()
+
+	// Sort and filter summary
+	sortDir := "desc"
+	if m.statsSortAsc {
+		sortDir = "asc"
+	}
+	summary := fmt.Sprintf("%s %s  %s %s",
+		labelStyle.Render("Tables:"), numberStyle.Render(fmt.Sprintf("%d/%d", len(rows), len(m.tableStats))),
+		labelStyle.Render("Sort:"), valueStyle.Render(m.statsSort.String()))
+	if m.statsSort != sortNone {
+		summary += mutedTextStyle.Render(" (" + sortDir + ")")
+	}
+	if n := len(m.statsSelected); n > 0 {
+		summary += fmt.Sprintf("  %s %s", labelStyle.Render("Selected:"), numberStyle.Render(fmt.Sprintf("%d", n)))
+	}
+	if m.statsFiltering {
+		summary += "  " + m.statsFilterInput.View()
+	} else if m.statsFilter != "" {
+		summary += fmt.Sprintf("  %s %s", labelStyle.Render("Filter:"), valueStyle.Render(m.statsFilter))
+	}
+	sb.WriteString(summary)
+	sb.WriteString("\n")
+	if len(rows) > 0 {
+		sb.WriteString(renderTableStatDetails(rows[min(m.statsCursor, len(rows)-1)], time.Now()))
 		sb.WriteString("\n")
+	}
+
+	if len(m.tableStats) == 0 {
+		rb.WriteString(warningTextStyle.Render("No table statistics available."))
+	} else if len(rows) == 0 {
+		rb.WriteString(warningTextStyle.Render("No tables match the filter."))
 	} else {
-		// Table header with colors
-		header := fmt.Sprintf("%-15s %-15s %-8s %-8s %-8s %-8s %-8s %-10s",
-			"SCHEMA", "TABLE", "INSERTS", "UPDATES", "DELETES", "DDLS", "ROWS", "STATE")
-		sb.WriteString(tableHeaderStyle.Render(header))
+		schemaWidth, tableWidth := m.tableStatsNameWidths()
+
+		// Table header with colors, marking the sorted column
+		headers := []string{"INSERTS", "UPDATES", "DELETES", "DDLS", "ROWS"}
+		sortHeaders := map[tableStatsSort]string{sortInserts: "INSERTS", sortUpdates: "UPDATES", sortDeletes: "DELETES", sortRows: "ROWS"}
+		arrow := "▼"
+		if m.statsSortAsc {
+			arrow = "▲"
+		}
+		for i, h := range headers {
+			if sortHeaders[m.statsSort] == h {
+				headers[i] = arrow + h
+			}
+		}
+		stateHeader := "STATE"
+		if m.statsSort == sortState {
+			stateHeader = arrow + stateHeader
+		}
+
+		columns := fmt.Sprintf("      %-*s %-*s %10s %10s %10s %10s %10s  %-*s %8s  %s",
+			schemaWidth, "SCHEMA", tableWidth, "TABLE",
+			headers[0], headers[1], headers[2], headers[3], headers[4],
+			sparklineWidth, "TREND", "RATE", stateHeader)
+		sb.WriteString("\n")
+		sb.WriteString(tableHeaderStyle.Render(columns))
 		sb.WriteString("\n")
 
-		separator := fmt.Sprintf("%-15s %-15s %-8s %-8s %-8s %-8s %-8s %-10s",
-			"───────────────", "───────────────", "────────", "────────", "────────", "────────", "────────", "──────────")
+		separator := fmt.Sprintf("      %s %s %s %s %s %s %s  %s %s  %s",
+			strings.Repeat("─", schemaWidth), strings.Repeat("─", tableWidth),
+			strings.Repeat("─", 10), strings.Repeat("─", 10), strings.Repeat("─", 10),
+			strings.Repeat("─", 10), strings.Repeat("─", 10),
+			strings.Repeat("─", sparklineWidth), strings.Repeat("─", 8), strings.Repeat("─", 10))
 		sb.WriteString(mutedTextStyle.Render(separator))
-		sb.WriteString("\n")
 
-		// Table rows with colored values
-		for _, s := range m.tableStats {
-			stateStyle := getTableValidationStyle(s.ValidationState)
-
-			sb.WriteString(fmt.Sprintf("%-15s %-15s %s %s %s %s %s %s\n",
-				valueStyle.Render(truncateString(s.SchemaName, 15)),
-				valueStyle.Render(truncateString(s.TableName, 15)),
-				numberStyle.Render(fmt.Sprintf("%-8d", s.Inserts)),
-				numberStyle.Render(fmt.Sprintf("%-8d", s.Updates)),
-				numberStyle.Render(fmt.Sprintf("%-8d", s.Deletes)),
-				numberStyle.Render(fmt.Sprintf("%-8d", s.Ddls)),
-				numberStyle.Render(fmt.Sprintf("%-8d", s.FullLoadRows)),
-				stateStyle.Render(fmt.Sprintf("%-10s", s.ValidationState)),
+		// Table rows with colored values; tables whose counters moved since
+		// the previous refresh are marked and highlighted
+		cursorRow := min(m.statsCursor, len(rows)-1)
+		for i, s := range rows {
+			marker := "  "
+			nameStyle := valueStyle
+			if m.changedTables[tableKey(s)] {
+				marker = changedMarkerStyle.Render("● ")
+				nameStyle = changedRowStyle
+			}
+			if i == cursorRow {
+				marker = selectedCursorStyle.Render("→ ")
+				nameStyle = selectedItemStyle
+			}
+			checkbox := mutedCheckboxStyle.Render("[ ]")
+			if m.statsSelected[tableKey(s)] {
+				checkbox = checkmarkStyle.Render("[✓]")
+			}
+
+			trend, rate := strings.Repeat(" ", sparklineWidth), ""
+			if w := m.throughput.Table(m.tableStatsARN, tableKey(s)); w != nil {
+				if latest, ok := w.Latest(); ok {
+					trend = dms.Sparkline(w.Totals(), sparklineWidth)
+					rate = dms.FormatRate(latest.Total())
+				}
+			}
+
+			rb.WriteString(fmt.Sprintf("%s%s %s %s %s %s %s %s %s  %s %s  %s\n",
+				marker,
+				checkbox,
+				nameStyle.Render(scrollString(s.SchemaName, m.statsOffset, schemaWidth)),
+				nameStyle.Render(scrollString(s.TableName, m.statsOffset, tableWidth)),
+				numberStyle.Render(fmt.Sprintf("%10d", s.Inserts)),
+				numberStyle.Render(fmt.Sprintf("%10d", s.Updates)),
+				numberStyle.Render(fmt.Sprintf("%10d", s.Deletes)),
+				numberStyle.Render(fmt.Sprintf("%10d", s.Ddls)),
+				numberStyle.Render(fmt.Sprintf("%10d", s.FullLoadRows)),
+				sparklineStyle.Render(trend),
+				numberStyle.Render(fmt.Sprintf("%8s", rate)),
+				getTableValidationStyle(s.ValidationState).Render(s.ValidationState),
 			))
 		}
+
+		// Totals over the visible tables
+		total := sumTableStats(rows)
+		rb.WriteString(mutedTextStyle.Render(separator))
+		rb.WriteString("\n")
+		rb.WriteString(fmt.Sprintf("      %s %s %s %s %s %s\n",
+			labelStyle.Render(fmt.Sprintf("%-*s", schemaWidth+tableWidth+1, "TOTAL")),
+			numberStyle.Render(fmt.Sprintf("%10d", total.Inserts)),
+			numberStyle.Render(fmt.Sprintf("%10d", total.Updates)),
+			numberStyle.Render(fmt.Sprintf("%10d", total.Deletes)),
+			numberStyle.Render(fmt.Sprintf("%10d", total.Ddls)),
+			numberStyle.Render(fmt.Sprintf("%10d", total.FullLoadRows)),
+		))
 	}
 
-	sb.WriteString("\n")
-	sb.WriteString(helpStyle.Render("Press [ESC] to go back"))
-	sb.WriteString("\n")
+	help = m.renderBindingsHelp(viewTableStats)
+	if m.statsFiltering {
+		help = helpStyle.Render("[enter] apply filter • [ESC] cancel")
+	}
+	return sb.String(), rb.String(), help
+}
 
-	return sb.String()
+// renderTableStatDetails renders the load state, error rows, full load,
+// applied changes and validation counters of the table under the cursor
+func renderTableStatDetails(s dms.TableStatistic, now time.Time) string {
+	var parts []string
+	if s.TableState != "" {
+		parts = append(parts, getTableStateStyle(s.TableState).Render(s.TableState))
+	}
+	if s.FullLoadErrorRows > 0 || s.FullLoadCondtnlChkFailedRows > 0 {
+		parts = append(parts, statusStoppedStyle.Render(fmt.Sprintf("%d error rows, %d failed checks", s.FullLoadErrorRows, s.FullLoadCondtnlChkFailedRows)))
+	}
+	if d, ok := s.FullLoadDuration(now); ok {
+		load := "full load took " + dms.FormatDuration(d)
+		if s.FullLoadEndTime == nil {
+			load = "full load running for " + dms.FormatDuration(d)
+		}
+		if s.FullLoadReloaded {
+			load += " (reloaded)"
+		}
+		parts = append(parts, valueStyle.Render(load))
+	}
+	parts = append(parts, valueStyle.Render(fmt.Sprintf("applied %d/%d/%d/%d I/U/D/DDL",
+		s.AppliedInserts, s.AppliedUpdates, s.AppliedDeletes, s.AppliedDdls)))
+	if counts := s.ValidationCounts(); counts != "" {
+		style := mutedTextStyle
+		if s.ValidationFailedRecords > 0 || s.ValidationSuspendedRecords > 0 {
+			style = statusStoppedStyle
+		}
+		parts = append(parts, style.Render("validation "+counts))
+	}
+	return labelStyle.Render(tableKey(s)+":") + " " + strings.Join(parts, mutedTextStyle.Render(" · "))
 }
 
-func (m Model) renderHelp() string {
-	autoRefreshStatus := mutedTextStyle.Render("off")
-	if m.autoRefresh {
-		autoRefreshStatus = statusRunningStyle.Render("on")
+// tableStatsNameWidths sizes the schema and table columns to the terminal,
+// leaving the counter and state columns at a fixed width
+func (m Model) tableStatsNameWidths() (int, int) {
+	width := m.width
+	if width == 0 {
+		width = 120
+	}
+
+	longestSchema, longestTable := len("SCHEMA"), len("TABLE")
+	for _, s := range m.tableStats {
+		longestSchema = max(longestSchema, len([]rune(s.SchemaName)))
+		longestTable = max(longestTable, len([]rune(s.TableName)))
 	}
 
-	helpLine1 := []string{
-		"[↑/k] up",
-		"[↓/j] down",
-		"[space] select",
-		"[enter] details",
-		"[s] start",
-		"[x] stop",
-		"[r] resume",
-		"[l] reload",
+	// Cursor and checkbox, five counter columns, trend and rate, and a state
+	// column of up to 20 characters
+	available := width - 6 - 5*11 - (sparklineWidth + 11) - 22 - 2
+	schemaWidth := min(longestSchema, max(available/3, len("SCHEMA")))
+	tableWidth := min(longestTable, max(available-schemaWidth, len("TABLE")+3))
+
+	return schemaWidth, tableWidth
+}
+
+// renderHelp renders the task list key bindings
+func (m Model) renderHelp() string {
+	return m.renderBindingsHelp(viewTaskList)
+}
+
+// renderEndpoint shows an endpoint by identifier and engine once endpoints
+// are loaded, and by ARN until then or for endpoints of other contexts
+func (m Model) renderEndpoint(arn string) string {
+	if ep, ok := m.endpoints[arn]; ok {
+		return valueStyle.Render(ep.Label())
 	}
+	return arnStyle.Render(arn)
+}
 
-	helpLine2 := []string{
-		"[c] clear",
-		"[f] refresh",
-		fmt.Sprintf("[a] auto-refresh: %s", autoRefreshStatus),
-		"[q] quit",
+// renderInstanceName shows a replication instance by identifier once
+// instances are loaded, and by ARN until then or for other contexts
+func (m Model) renderInstanceName(arn string) string {
+	for _, inst := range m.instances {
+		if inst.ARN == arn {
+			return valueStyle.Render(inst.Identifier)
+		}
 	}
+	return arnStyle.Render(arn)
+}
 
-	return helpStyle.Render(strings.Join(helpLine1, " • ") + "\n" + strings.Join(helpLine2, " • "))
+// getTableStateStyle returns color based on the load state of a table
+func getTableStateStyle(state string) lipglossStyle {
+	switch strings.ToLower(state) {
+	case "table completed":
+		return statusRunningStyle
+	case "table error", "table cancelled":
+		return statusStoppedStyle
+	default:
+		return statusOtherStyle
+	}
 }
 
 // getTableValidationStyle returns color based on validation state
@@ -0,0 +1,594 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/eljosho/dms-manager/pkg/dms"
+)
+
+// Task creation wizard steps
+const (
+	wizardName = iota
+	wizardInstance
+	wizardSource
+	wizardTarget
+	wizardMigrationType
+	wizardMappings
+	wizardSettings
+	wizardPreview
+	wizardStepCount
+)
+
+// wizardStepTitles names the wizard steps in order
+var wizardStepTitles = [wizardStepCount]string{
+	"Task name",
+	"Replication instance",
+	"Source endpoint",
+	"Target endpoint",
+	"Migration type",
+	"Table mappings",
+	"Task settings",
+	"Review",
+}
+
+// taskWizard holds the state of the task creation wizard
+type taskWizard struct {
+	step   int
+	cursor int
+
+	nameInput textinput.Model
+	ruleInput textinput.Model
+
+	// ruleAction is the action of the selection rule being typed, or empty
+	// while no rule is being edited
+	ruleAction string
+
+	loading   bool
+	instances []dms.ReplicationInstance
+	endpoints []dms.Endpoint
+
+	req      dms.CreateTaskRequest
+	rules    []dms.SelectionRule
+	template int
+
+	submitting bool
+	err        error
+}
+
+type wizardResourcesMsg struct {
+	instances []dms.ReplicationInstance
+	endpoints []dms.Endpoint
+	err       error
+}
+
+type taskCreatedMsg struct {
+	task *dms.Task
+	err  error
+}
+
+// LoadWizardResourcesCmd loads the replication instances and endpoints the
+// wizard offers
+func LoadWizardResourcesCmd(client *dms.Client) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		instances, err := client.ListReplicationInstances(ctx)
+		if err != nil {
+			return wizardResourcesMsg{err: err}
+		}
+		endpoints, err := client.ListEndpoints(ctx)
+		return wizardResourcesMsg{instances: instances, endpoints: endpoints, err: err}
+	}
+}
+
+// CreateTaskCmd creates a replication task asynchronously
+func CreateTaskCmd(client *dms.Client, req dms.CreateTaskRequest) tea.Cmd {
+	return func() tea.Msg {
+		task, err := client.CreateTask(context.Background(), req)
+		return taskCreatedMsg{task: task, err: err}
+	}
+}
+
+// openWizard starts the task creation wizard in the active context
+func (m Model) openWizard() (tea.Model, tea.Cmd) {
+	if m.readOnly {
+		m.operationMsg = "Read-only mode: task creation is disabled"
+		return m, nil
+	}
+
+	name := textinput.New()
+	name.Prompt = "> "
+	name.Placeholder = "task-identifier"
+	name.CharLimit = 255
+	name.Cursor.SetMode(cursor.CursorStatic)
+
+	rule := textinput.New()
+	rule.Prompt = "> "
+	rule.Placeholder = "schema.table (% matches any name)"
+	rule.Cursor.SetMode(cursor.CursorStatic)
+
+	m.wizard = taskWizard{
+		nameInput: name,
+		ruleInput: rule,
+		loading:   true,
+		req:       dms.CreateTaskRequest{MigrationType: dms.MigrationTypes[0]},
+		rules:     []dms.SelectionRule{{Schema: "%", Table: "%", Action: "include"}},
+	}
+	m.state = viewCreateTask
+	m.operationMsg = ""
+	return m, tea.Batch(m.wizard.nameInput.Focus(), LoadWizardResourcesCmd(m.client))
+}
+
+// sourceEndpoints and targetEndpoints filter the endpoints by role
+func (w taskWizard) sourceEndpoints() []dms.Endpoint {
+	var eps []dms.Endpoint
+	for _, e := range w.endpoints {
+		if e.IsSource() {
+			eps = append(eps, e)
+		}
+	}
+	return eps
+}
+
+func (w taskWizard) targetEndpoints() []dms.Endpoint {
+	var eps []dms.Endpoint
+	for _, e := range w.endpoints {
+		if e.IsTarget() {
+			eps = append(eps, e)
+		}
+	}
+	return eps
+}
+
+// options returns the choices of the current list step
+func (w taskWizard) options() []string {
+	var opts []string
+	switch w.step {
+	case wizardInstance:
+		for _, inst := range w.instances {
+			opts = append(opts, fmt.Sprintf("%s (%s, %s)", inst.Identifier, inst.Class, inst.Status))
+		}
+	case wizardSource:
+		for _, e := range w.sourceEndpoints() {
+			opts = append(opts, fmt.Sprintf("%s (%s, %s)", e.Identifier, e.Engine, e.Status))
+		}
+	case wizardTarget:
+		for _, e := range w.targetEndpoints() {
+			opts = append(opts, fmt.Sprintf("%s (%s, %s)", e.Identifier, e.Engine, e.Status))
+		}
+	case wizardMigrationType:
+		opts = dms.MigrationTypes
+	case wizardSettings:
+		for _, t := range dms.SettingsTemplates {
+			opts = append(opts, fmt.Sprintf("%s - %s", t.Name, t.Description))
+		}
+	}
+	return opts
+}
+
+// choose records the option under the cursor for the current list step
+func (w *taskWizard) choose() {
+	switch w.step {
+	case wizardInstance:
+		w.req.ReplicationInstanceARN = w.instances[w.cursor].ARN
+	case wizardSource:
+		w.req.SourceEndpointARN = w.sourceEndpoints()[w.cursor].ARN
+	case wizardTarget:
+		w.req.TargetEndpointARN = w.targetEndpoints()[w.cursor].ARN
+	case wizardMigrationType:
+		w.req.MigrationType = dms.MigrationTypes[w.cursor]
+	case wizardSettings:
+		w.template = w.cursor
+		w.req.TaskSettings = dms.SettingsTemplates[w.cursor].Settings
+	}
+}
+
+// selectedIndex returns the option of a list step chosen earlier, so going
+// back keeps the cursor on it
+func (w taskWizard) selectedIndex() int {
+	switch w.step {
+	case wizardInstance:
+		for i, inst := range w.instances {
+			if inst.ARN == w.req.ReplicationInstanceARN {
+				return i
+			}
+		}
+	case wizardSource:
+		for i, e := range w.sourceEndpoints() {
+			if e.ARN == w.req.SourceEndpointARN {
+				return i
+			}
+		}
+	case wizardTarget:
+		for i, e := range w.targetEndpoints() {
+			if e.ARN == w.req.TargetEndpointARN {
+				return i
+			}
+		}
+	case wizardMigrationType:
+		return indexOf(dms.MigrationTypes, w.req.MigrationType)
+	case wizardSettings:
+		return w.template
+	case wizardMappings:
+		return max(len(w.rules)-1, 0)
+	}
+	return 0
+}
+
+// goTo moves to a step, placing the cursor on its current choice
+func (w *taskWizard) goTo(step int) {
+	w.step = step
+	w.err = nil
+	w.cursor = w.selectedIndex()
+}
+
+// request completes the request with the table mappings built from the rules
+func (w taskWizard) request() (dms.CreateTaskRequest, error) {
+	req := w.req
+	mappings, err := dms.BuildTableMappings(w.rules)
+	if err != nil {
+		return req, err
+	}
+	req.TableMappings = mappings
+	return req, req.Validate()
+}
+
+func (m Model) handleWizardKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	w := &m.wizard
+	key := msg.String()
+
+	if key == "ctrl+c" {
+		return m, tea.Quit
+	}
+	if w.submitting {
+		return m, nil
+	}
+
+	switch {
+	case w.step == wizardName:
+		return m.handleWizardNameKeys(msg)
+	case w.step == wizardMappings && w.ruleAction != "":
+		return m.handleWizardRuleKeys(msg)
+	case w.step == wizardMappings:
+		return m.handleWizardMappingsKeys(key)
+	case w.step == wizardPreview:
+		return m.handleWizardPreviewKeys(key)
+	}
+
+	// List steps
+	opts := w.options()
+	switch key {
+	case "up", "k":
+		if w.cursor > 0 {
+			w.cursor--
+		}
+	case "down", "j":
+		if w.cursor < len(opts)-1 {
+			w.cursor++
+		}
+	case "enter":
+		if w.cursor >= len(opts) {
+			return m, nil
+		}
+		w.choose()
+		w.goTo(w.step + 1)
+	case "esc", "backspace":
+		w.goTo(w.step - 1)
+		if w.step == wizardName {
+			return m, w.nameInput.Focus()
+		}
+	}
+	return m, nil
+}
+
+func (m Model) handleWizardNameKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	w := &m.wizard
+
+	switch msg.String() {
+	case "esc":
+		m.state = viewTaskList
+		return m, nil
+
+	case "enter":
+		name := strings.TrimSpace(w.nameInput.Value())
+		if err := dms.ValidateTaskIdentifier(name); err != nil {
+			w.err = err
+			return m, nil
+		}
+		if w.loading {
+			w.err = fmt.Errorf("still loading replication instances and endpoints")
+			return m, nil
+		}
+		w.req.Identifier = name
+		w.nameInput.Blur()
+		w.goTo(wizardInstance)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	w.nameInput, cmd = w.nameInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) handleWizardMappingsKeys(key string) (tea.Model, tea.Cmd) {
+	w := &m.wizard
+
+	switch key {
+	case "up", "k":
+		if w.cursor > 0 {
+			w.cursor--
+		}
+	case "down", "j":
+		if w.cursor < len(w.rules)-1 {
+			w.cursor++
+		}
+	case "i", "x":
+		w.ruleAction = "include"
+		if key == "x" {
+			w.ruleAction = "exclude"
+		}
+		w.err = nil
+		w.ruleInput.SetValue("")
+		return m, w.ruleInput.Focus()
+	case "d", "delete":
+		if w.cursor < len(w.rules) {
+			w.rules = append(w.rules[:w.cursor], w.rules[w.cursor+1:]...)
+			w.cursor = min(w.cursor, max(len(w.rules)-1, 0))
+		}
+	case "enter":
+		if _, err := dms.BuildTableMappings(w.rules); err != nil {
+			w.err = err
+			return m, nil
+		}
+		w.goTo(wizardSettings)
+	case "esc", "backspace":
+		w.goTo(wizardMigrationType)
+	}
+	return m, nil
+}
+
+func (m Model) handleWizardRuleKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	w := &m.wizard
+
+	switch msg.String() {
+	case "esc":
+		w.ruleAction = ""
+		w.ruleInput.Blur()
+		return m, nil
+
+	case "enter":
+		rule, err := dms.ParseSelectionRule(w.ruleInput.Value(), w.ruleAction)
+		if err != nil {
+			w.err = err
+			return m, nil
+		}
+		w.rules = append(w.rules, rule)
+		w.cursor = len(w.rules) - 1
+		w.ruleAction = ""
+		w.err = nil
+		w.ruleInput.Blur()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	w.ruleInput, cmd = w.ruleInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) handleWizardPreviewKeys(key string) (tea.Model, tea.Cmd) {
+	w := &m.wizard
+
+	req, err := w.request()
+	switch key {
+	case "enter":
+		if err != nil {
+			w.err = err
+			return m, nil
+		}
+		w.submitting = true
+		w.err = nil
+		return m, CreateTaskCmd(m.client, req)
+
+	case "y":
+		preview, perr := req.PreviewJSON()
+		if perr != nil {
+			w.err = perr
+			return m, nil
+		}
+		return m, CopyCmd(preview, "request JSON", false)
+
+	case "esc", "backspace":
+		w.goTo(wizardSettings)
+	}
+	return m, nil
+}
+
+// handleTaskCreated returns to the task list after a task was created, or
+// shows the error on the review step
+func (m Model) handleTaskCreated(msg taskCreatedMsg) (tea.Model, tea.Cmd) {
+	m.wizard.submitting = false
+	m.health.record(msg.err, time.Now())
+	if msg.err != nil {
+		m.wizard.err = msg.err
+		return m, nil
+	}
+
+	m.state = viewTaskList
+	m.operationMsg = fmt.Sprintf("Created task %s (%s); start it once it is ready", msg.task.Name, msg.task.Status)
+	m.scheduler.Reset()
+	return m, m.loadTasksCmd()
+}
+
+func (m Model) renderWizard() string {
+	w := m.wizard
+	var sb strings.Builder
+
+	sb.WriteString(titleStyle.Render(fmt.Sprintf("Create Replication Task - %s", clientContextName(m.client))))
+	sb.WriteString("\n")
+	sb.WriteString(mutedTextStyle.Render(fmt.Sprintf("Step %d/%d: %s", w.step+1, wizardStepCount, wizardStepTitles[w.step])))
+	sb.WriteString("\n\n")
+
+	// Choices made so far
+	if w.step > wizardName {
+		sb.WriteString(m.renderWizardChoices())
+		sb.WriteString("\n")
+	}
+
+	switch w.step {
+	case wizardName:
+		sb.WriteString(w.nameInput.View())
+		sb.WriteString("\n")
+		if w.loading {
+			sb.WriteString("\n")
+			sb.WriteString(mutedTextStyle.Render(m.spinner.View() + " Loading replication instances and endpoints..."))
+			sb.WriteString("\n")
+		}
+
+	case wizardMappings:
+		sb.WriteString(m.renderWizardRules())
+
+	case wizardPreview:
+		sb.WriteString(m.renderWizardPreview())
+
+	default:
+		opts := w.options()
+		if len(opts) == 0 {
+			sb.WriteString(warningTextStyle.Render(fmt.Sprintf("No %s found in this region.", strings.ToLower(wizardStepTitles[w.step])+"s")))
+			sb.WriteString("\n")
+		}
+		for i, opt := range opts {
+			cursor := "  "
+			nameStyle := normalItemStyle
+			if i == w.cursor {
+				cursor = selectedCursorStyle.Render("→ ")
+				nameStyle = selectedItemStyle
+			}
+			sb.WriteString(cursor + nameStyle.Render(opt) + "\n")
+		}
+	}
+
+	if w.submitting {
+		sb.WriteString("\n")
+		sb.WriteString(infoStyle.Render(m.spinner.View() + " Creating task..."))
+		sb.WriteString("\n")
+	}
+	if w.err != nil {
+		sb.WriteString(errorStyle.Render(w.err.Error()))
+		sb.WriteString("\n")
+	}
+	if m.operationMsg != "" && w.step == wizardPreview {
+		sb.WriteString(infoStyle.Render(m.operationMsg))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(helpStyle.Render(wizardHelp(w)))
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// wizardHelp returns the key help of the current step
+func wizardHelp(w taskWizard) string {
+	switch {
+	case w.step == wizardName:
+		return "Press [enter] next • [ESC] cancel"
+	case w.step == wizardMappings && w.ruleAction != "":
+		return fmt.Sprintf("Type a pattern to %s • [enter] add rule • [ESC] cancel", w.ruleAction)
+	case w.step == wizardMappings:
+		return "Press [i] include • [x] exclude • [d] delete rule • [enter] next • [ESC] back"
+	case w.step == wizardPreview:
+		return "Press [enter] create task • [y] copy JSON • [ESC] back"
+	default:
+		return "Press [enter] choose • [ESC] back"
+	}
+}
+
+// renderWizardChoices summarizes the choices of the completed steps
+func (m Model) renderWizardChoices() string {
+	w := m.wizard
+
+	var rows [][2]string
+	rows = append(rows, [2]string{"Name:", w.req.Identifier})
+	if w.step > wizardInstance {
+		rows = append(rows, [2]string{"Instance:", truncateARN(w.req.ReplicationInstanceARN)})
+	}
+	if w.step > wizardSource {
+		rows = append(rows, [2]string{"Source:", truncateARN(w.req.SourceEndpointARN)})
+	}
+	if w.step > wizardTarget {
+		rows = append(rows, [2]string{"Target:", truncateARN(w.req.TargetEndpointARN)})
+	}
+	if w.step > wizardMigrationType {
+		rows = append(rows, [2]string{"Type:", w.req.MigrationType})
+	}
+	if w.step > wizardMappings {
+		rows = append(rows, [2]string{"Rules:", fmt.Sprintf("%d selection rules", len(w.rules))})
+	}
+	if w.step > wizardSettings {
+		rows = append(rows, [2]string{"Settings:", dms.SettingsTemplates[w.template].Name})
+	}
+
+	var sb strings.Builder
+	for _, r := range rows {
+		sb.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render(fmt.Sprintf("%-9s", r[0])), valueStyle.Render(r[1])))
+	}
+	return sb.String()
+}
+
+// renderWizardRules renders the selection rules being built
+func (m Model) renderWizardRules() string {
+	w := m.wizard
+	var sb strings.Builder
+
+	if len(w.rules) == 0 {
+		sb.WriteString(warningTextStyle.Render("No rules yet; add at least one include rule."))
+		sb.WriteString("\n")
+	}
+	for i, r := range w.rules {
+		cursor := "  "
+		if i == w.cursor && w.ruleAction == "" {
+			cursor = selectedCursorStyle.Render("→ ")
+		}
+		sb.WriteString(fmt.Sprintf("%s%s %s %s\n", cursor,
+			numberStyle.Render(fmt.Sprintf("#%d", i+1)),
+			mappingActionStyle(r.Action).Render(fmt.Sprintf("%-7s", r.Action)),
+			valueStyle.Render(r.Schema+"."+r.Table)))
+	}
+
+	if w.ruleAction != "" {
+		sb.WriteString("\n")
+		sb.WriteString(labelStyle.Render(fmt.Sprintf("New %s rule:", w.ruleAction)))
+		sb.WriteString("\n")
+		sb.WriteString(w.ruleInput.View())
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// renderWizardPreview renders the request that will be sent
+func (m Model) renderWizardPreview() string {
+	req, err := m.wizard.request()
+	preview, perr := req.PreviewJSON()
+	if perr != nil {
+		return errorTextStyle.Render(perr.Error()) + "\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(labelStyle.Render("CreateReplicationTask request:"))
+	sb.WriteString("\n")
+	for _, l := range strings.Split(preview, "\n") {
+		sb.WriteString(highlightJSONLine(l))
+		sb.WriteString("\n")
+	}
+	if err != nil {
+		sb.WriteString(warningTextStyle.Render("Incomplete: " + err.Error()))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
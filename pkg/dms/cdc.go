@@ -0,0 +1,114 @@
+package dms
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// cdcTimeLayout is the timestamp format DMS uses in CDC positions
+const cdcTimeLayout = "2006-01-02T15:04:05"
+
+// CDCOptions positions change data capture when a task starts. The zero
+// value lets DMS pick the position, which is where the task left off when
+// resuming.
+type CDCOptions struct {
+	// StartTime starts CDC from the changes made at this time. It cannot be
+	// combined with StartPosition.
+	StartTime *time.Time
+
+	// StartPosition starts CDC from a native position of the source, such
+	// as an LSN or SCN, a recovery checkpoint, or a "2006-01-02T15:04:05"
+	// timestamp
+	StartPosition string
+
+	// StopPosition stops CDC at "server_time:2006-01-02T15:04:05" or
+	// "commit_time:2006-01-02T15:04:05"
+	StopPosition string
+}
+
+// IsZero reports whether no CDC position is set
+func (o CDCOptions) IsZero() bool {
+	return o.StartTime == nil && o.StartPosition == "" && o.StopPosition == ""
+}
+
+// IsCheckpoint reports whether a CDC start position is a recovery checkpoint
+// as reported by DMS, rather than a native position or a time
+func IsCheckpoint(position string) bool {
+	return strings.HasPrefix(position, "checkpoint:")
+}
+
+// Validate checks the options on their own and against the migration type of
+// a task. Only CDC-only tasks can start from a given position or time, while
+// any task that replicates changes can resume from a recovery checkpoint or
+// be given a stop position.
+func (o CDCOptions) Validate(task Task) error {
+	if o.StartTime != nil && o.StartPosition != "" {
+		return fmt.Errorf("use either a CDC start time or a CDC start position, not both")
+	}
+	if o.StopPosition != "" {
+		if _, err := ParseCDCStopPosition(o.StopPosition); err != nil {
+			return err
+		}
+	}
+
+	replicatesChanges := strings.Contains(task.MigrationType, "cdc")
+	if IsCheckpoint(o.StartPosition) {
+		if !replicatesChanges {
+			return fmt.Errorf("%s: a recovery checkpoint needs a task that replicates changes, not %s", task.Name, task.MigrationType)
+		}
+	} else if (o.StartTime != nil || o.StartPosition != "") && task.MigrationType != "cdc" {
+		return fmt.Errorf("%s: a CDC start time or position needs a cdc task, not %s", task.Name, task.MigrationType)
+	}
+	if o.StopPosition != "" && !replicatesChanges {
+		return fmt.Errorf("%s: a CDC stop position needs a task that replicates changes, not %s", task.Name, task.MigrationType)
+	}
+	return nil
+}
+
+// Describe summarizes the options for confirmation prompts, e.g. "CDC from
+// 2024-05-01T10:00:00Z until commit_time:2024-05-01T12:00:00"
+func (o CDCOptions) Describe() string {
+	var parts []string
+	switch {
+	case o.StartTime != nil:
+		parts = append(parts, "CDC from "+o.StartTime.UTC().Format(time.RFC3339))
+	case IsCheckpoint(o.StartPosition):
+		parts = append(parts, "CDC from a recovery checkpoint")
+	case o.StartPosition != "":
+		parts = append(parts, "CDC from position "+o.StartPosition)
+	}
+	if o.StopPosition != "" {
+		if len(parts) == 0 {
+			parts = append(parts, "CDC")
+		}
+		parts = append(parts, "until "+o.StopPosition)
+	}
+	return strings.Join(parts, " ")
+}
+
+// ParseCDCStartTime parses a CDC start time given as RFC 3339, or as
+// "2006-01-02T15:04:05" or "2006-01-02 15:04:05" in UTC
+func ParseCDCStartTime(s string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, cdcTimeLayout, "2006-01-02 15:04:05"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid CDC start time %q (use RFC 3339, e.g. 2024-05-01T10:00:00Z)", s)
+}
+
+// ParseCDCStopPosition checks a CDC stop position and returns it in the form
+// DMS expects. The prefix is case-insensitive and the time is in UTC.
+func ParseCDCStopPosition(s string) (string, error) {
+	kind, value, ok := strings.Cut(s, ":")
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	if !ok || (kind != "server_time" && kind != "commit_time") {
+		return "", fmt.Errorf("invalid CDC stop position %q (use server_time:<time> or commit_time:<time>)", s)
+	}
+	t, err := time.Parse(cdcTimeLayout, strings.TrimSpace(value))
+	if err != nil {
+		return "", fmt.Errorf("invalid CDC stop position %q: time must look like 2006-01-02T15:04:05", s)
+	}
+	return kind + ":" + t.Format(cdcTimeLayout), nil
+}
@@ -0,0 +1,71 @@
+package dms
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Resource kinds with a page in the DMS console
+const (
+	ResourceTask     = "task"
+	ResourceInstance = "instance"
+	ResourceEndpoint = "endpoint"
+)
+
+// consoleHost returns the console host name for a region's partition
+func consoleHost(region string) string {
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return "console.amazonaws.cn"
+	case strings.HasPrefix(region, "us-gov-"):
+		return "console.amazonaws-us-gov.com"
+	default:
+		return region + ".console.aws.amazon.com"
+	}
+}
+
+// ConsoleURL returns the DMS console page of a resource. An empty
+// identifier links to the resource list instead.
+func ConsoleURL(region, kind, identifier string) string {
+	base := fmt.Sprintf("https://%s/dms/v2/home?region=%s", consoleHost(region), url.QueryEscape(region))
+
+	var list, details string
+	switch kind {
+	case ResourceInstance:
+		list, details = "replicationInstances", "replicationInstanceDetails"
+	case ResourceEndpoint:
+		list, details = "endpointList", "endpointDetails"
+	default:
+		list, details = "tasks", "taskDetails"
+	}
+
+	if identifier == "" {
+		return base + "#" + list
+	}
+	return base + "#" + details + "/" + url.PathEscape(identifier)
+}
+
+// ResourceIdentifier looks up the identifier of a replication instance or
+// endpoint, which the console uses instead of the ARN
+func (c *Client) ResourceIdentifier(ctx context.Context, kind, arn string) (string, error) {
+	switch kind {
+	case ResourceInstance:
+		inst, err := c.DescribeReplicationInstance(ctx, arn)
+		if err != nil {
+			return "", err
+		}
+		return inst.Identifier, nil
+
+	case ResourceEndpoint:
+		ep, err := c.DescribeEndpoint(ctx, arn)
+		if err != nil {
+			return "", err
+		}
+		return ep.Identifier, nil
+
+	default:
+		return "", fmt.Errorf("unknown resource kind %q", kind)
+	}
+}
@@ -0,0 +1,193 @@
+package dms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/databasemigrationservice"
+	"github.com/aws/aws-sdk-go-v2/service/databasemigrationservice/types"
+)
+
+// MigrationTypes lists the migration types a task can be created with
+var MigrationTypes = []string{
+	string(types.MigrationTypeValueFullLoad),
+	string(types.MigrationTypeValueCdc),
+	string(types.MigrationTypeValueFullLoadAndCdc),
+}
+
+// SettingsTemplate is a named set of task settings to create a task with
+type SettingsTemplate struct {
+	Name        string
+	Description string
+	Settings    string // JSON; empty for the DMS defaults
+}
+
+// SettingsTemplates lists the task settings templates offered when creating
+// a task
+var SettingsTemplates = []SettingsTemplate{
+	{
+		Name:        "dms-default",
+		Description: "DMS default settings",
+	},
+	{
+		Name:        "limited-lob",
+		Description: "Limited LOB mode with 32 KB LOBs and CloudWatch logging",
+		Settings:    `{"TargetMetadata":{"SupportLobs":true,"FullLobMode":false,"LimitedSizeLobMode":true,"LobMaxSize":32},"Logging":{"EnableLogging":true}}`,
+	},
+	{
+		Name:        "full-lob",
+		Description: "Full LOB mode in 64 KB chunks and CloudWatch logging",
+		Settings:    `{"TargetMetadata":{"SupportLobs":true,"FullLobMode":true,"LobChunkSize":64},"Logging":{"EnableLogging":true}}`,
+	},
+	{
+		Name:        "validation",
+		Description: "Data validation and CloudWatch logging",
+		Settings:    `{"ValidationSettings":{"EnableValidation":true,"ThreadCount":5},"Logging":{"EnableLogging":true}}`,
+	},
+	{
+		Name:        "batch-apply",
+		Description: "Batch apply of CDC changes and CloudWatch logging",
+		Settings:    `{"TargetMetadata":{"BatchApplyEnabled":true},"Logging":{"EnableLogging":true}}`,
+	},
+}
+
+// CreateTaskRequest describes a replication task to create
+type CreateTaskRequest struct {
+	Identifier             string
+	ReplicationInstanceARN string
+	SourceEndpointARN      string
+	TargetEndpointARN      string
+	MigrationType          string
+	TableMappings          string
+	TaskSettings           string // optional
+}
+
+// ValidateTaskIdentifier checks a task identifier against the DMS naming
+// rules: 1-255 letters, digits or hyphens, starting with a letter, without
+// two consecutive hyphens or a trailing hyphen
+func ValidateTaskIdentifier(id string) error {
+	switch {
+	case id == "":
+		return fmt.Errorf("task identifier is required")
+	case len(id) > 255:
+		return fmt.Errorf("task identifier must be at most 255 characters")
+	case !isLetter(rune(id[0])):
+		return fmt.Errorf("task identifier must start with a letter")
+	case strings.Contains(id, "--"):
+		return fmt.Errorf("task identifier must not contain two consecutive hyphens")
+	case strings.HasSuffix(id, "-"):
+		return fmt.Errorf("task identifier must not end with a hyphen")
+	}
+	for _, r := range id {
+		if !isLetter(r) && (r < '0' || r > '9') && r != '-' {
+			return fmt.Errorf("task identifier may only contain letters, digits and hyphens")
+		}
+	}
+	return nil
+}
+
+func isLetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+// Validate checks that the request is complete before sending it
+func (r CreateTaskRequest) Validate() error {
+	if err := ValidateTaskIdentifier(r.Identifier); err != nil {
+		return err
+	}
+	if r.ReplicationInstanceARN == "" {
+		return fmt.Errorf("replication instance is required")
+	}
+	if r.SourceEndpointARN == "" || r.TargetEndpointARN == "" {
+		return fmt.Errorf("source and target endpoints are required")
+	}
+	if r.SourceEndpointARN == r.TargetEndpointARN {
+		return fmt.Errorf("source and target endpoints must differ")
+	}
+
+	valid := false
+	for _, t := range MigrationTypes {
+		valid = valid || t == r.MigrationType
+	}
+	if !valid {
+		return fmt.Errorf("invalid migration type %q (valid: %s)", r.MigrationType, strings.Join(MigrationTypes, ", "))
+	}
+
+	tm, err := ParseTableMappings(r.TableMappings)
+	if err != nil {
+		return err
+	}
+	included := false
+	for _, rule := range tm.RulesOfType(RuleTypeSelection) {
+		included = included || rule.Action == "include"
+	}
+	if !included {
+		return fmt.Errorf("table mappings need at least one include rule")
+	}
+
+	if r.TaskSettings != "" && !json.Valid([]byte(r.TaskSettings)) {
+		return fmt.Errorf("task settings are not valid JSON")
+	}
+	return nil
+}
+
+// PreviewJSON renders the request in the format accepted by
+// `aws dms create-replication-task --cli-input-json`
+func (r CreateTaskRequest) PreviewJSON() (string, error) {
+	doc := struct {
+		ReplicationTaskIdentifier string
+		ReplicationInstanceArn    string
+		SourceEndpointArn         string
+		TargetEndpointArn         string
+		MigrationType             string
+		TableMappings             string
+		ReplicationTaskSettings   string `json:",omitempty"`
+	}{
+		ReplicationTaskIdentifier: r.Identifier,
+		ReplicationInstanceArn:    r.ReplicationInstanceARN,
+		SourceEndpointArn:         r.SourceEndpointARN,
+		TargetEndpointArn:         r.TargetEndpointARN,
+		MigrationType:             r.MigrationType,
+		TableMappings:             r.TableMappings,
+		ReplicationTaskSettings:   r.TaskSettings,
+	}
+
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to render request: %w", err)
+	}
+	return string(out), nil
+}
+
+// CreateTask creates a replication task. The task starts in the creating
+// state and has to be started once it is ready.
+func (c *Client) CreateTask(ctx context.Context, req CreateTaskRequest) (*Task, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	input := &databasemigrationservice.CreateReplicationTaskInput{
+		ReplicationTaskIdentifier: stringPtr(req.Identifier),
+		ReplicationInstanceArn:    stringPtr(req.ReplicationInstanceARN),
+		SourceEndpointArn:         stringPtr(req.SourceEndpointARN),
+		TargetEndpointArn:         stringPtr(req.TargetEndpointARN),
+		MigrationType:             types.MigrationTypeValue(req.MigrationType),
+		TableMappings:             stringPtr(req.TableMappings),
+	}
+	if req.TaskSettings != "" {
+		input.ReplicationTaskSettings = stringPtr(req.TaskSettings)
+	}
+
+	output, err := c.svc.CreateReplicationTask(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create task: %w", err)
+	}
+	if output.ReplicationTask == nil {
+		return nil, fmt.Errorf("failed to create task: empty response")
+	}
+
+	task := convertTask(*output.ReplicationTask)
+	return &task, nil
+}
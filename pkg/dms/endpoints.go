@@ -0,0 +1,171 @@
+package dms
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/databasemigrationservice"
+	"github.com/aws/aws-sdk-go-v2/service/databasemigrationservice/types"
+)
+
+// ListEndpoints retrieves all source and target endpoints
+func (c *Client) ListEndpoints(ctx context.Context) ([]Endpoint, error) {
+	input := &databasemigrationservice.DescribeEndpointsInput{}
+
+	var endpoints []Endpoint
+	paginator := databasemigrationservice.NewDescribeEndpointsPaginator(c.svc, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list endpoints: %w", err)
+		}
+
+		for _, ep := range output.Endpoints {
+			endpoints = append(endpoints, convertEndpoint(ep))
+		}
+	}
+
+	return endpoints, nil
+}
+
+// DescribeEndpoint retrieves a single endpoint by ARN or identifier
+func (c *Client) DescribeEndpoint(ctx context.Context, endpoint string) (*Endpoint, error) {
+	filter := "endpoint-id"
+	if strings.HasPrefix(endpoint, "arn:") {
+		filter = "endpoint-arn"
+	}
+
+	input := &databasemigrationservice.DescribeEndpointsInput{
+		Filters: []types.Filter{
+			{
+				Name:   stringPtr(filter),
+				Values: []string{endpoint},
+			},
+		},
+	}
+
+	output, err := c.svc.DescribeEndpoints(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe endpoint: %w", err)
+	}
+
+	if len(output.Endpoints) == 0 {
+		return nil, fmt.Errorf("endpoint not found: %s", endpoint)
+	}
+
+	ep := convertEndpoint(output.Endpoints[0])
+	return &ep, nil
+}
+
+// EndpointsByARN indexes endpoints by ARN, for resolving the endpoint ARNs
+// of tasks to identifiers and engines
+func EndpointsByARN(endpoints []Endpoint) map[string]Endpoint {
+	byARN := make(map[string]Endpoint, len(endpoints))
+	for _, ep := range endpoints {
+		byARN[ep.ARN] = ep
+	}
+	return byARN
+}
+
+// TestConnection starts a connection test between a replication instance and
+// an endpoint. The test runs asynchronously; use WaitForConnection or
+// DescribeConnections for its result.
+func (c *Client) TestConnection(ctx context.Context, instanceARN, endpointARN string) (*Connection, error) {
+	input := &databasemigrationservice.TestConnectionInput{
+		ReplicationInstanceArn: &instanceARN,
+		EndpointArn:            &endpointARN,
+	}
+
+	output, err := c.svc.TestConnection(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to test connection: %w", err)
+	}
+
+	if output.Connection == nil {
+		return nil, fmt.Errorf("no connection returned for %s", endpointARN)
+	}
+
+	conn := convertConnection(*output.Connection)
+	return &conn, nil
+}
+
+// DescribeConnections retrieves the results of connection tests, optionally
+// limited to a replication instance and/or an endpoint. Empty ARNs match all.
+func (c *Client) DescribeConnections(ctx context.Context, instanceARN, endpointARN string) ([]Connection, error) {
+	input := &databasemigrationservice.DescribeConnectionsInput{}
+	if instanceARN != "" {
+		input.Filters = append(input.Filters, types.Filter{
+			Name:   stringPtr("replication-instance-arn"),
+			Values: []string{instanceARN},
+		})
+	}
+	if endpointARN != "" {
+		input.Filters = append(input.Filters, types.Filter{
+			Name:   stringPtr("endpoint-arn"),
+			Values: []string{endpointARN},
+		})
+	}
+
+	var connections []Connection
+	paginator := databasemigrationservice.NewDescribeConnectionsPaginator(c.svc, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe connections: %w", err)
+		}
+
+		for _, conn := range output.Connections {
+			connections = append(connections, convertConnection(conn))
+		}
+	}
+
+	return connections, nil
+}
+
+// WaitForConnection polls the connection between a replication instance and
+// an endpoint until its test has a final result or ctx is done
+func (c *Client) WaitForConnection(ctx context.Context, instanceARN, endpointARN string, interval time.Duration) (*Connection, error) {
+	for {
+		connections, err := c.DescribeConnections(ctx, instanceARN, endpointARN)
+		if err != nil {
+			return nil, err
+		}
+		if len(connections) > 0 && connections[0].IsFinal() {
+			return &connections[0], nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("connection test did not finish: %w", ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
+
+func convertEndpoint(ep types.Endpoint) Endpoint {
+	return Endpoint{
+		ARN:          stringValue(ep.EndpointArn),
+		Identifier:   stringValue(ep.EndpointIdentifier),
+		Type:         string(ep.EndpointType),
+		Engine:       stringValue(ep.EngineName),
+		Status:       stringValue(ep.Status),
+		ServerName:   stringValue(ep.ServerName),
+		Port:         int32Value(ep.Port),
+		DatabaseName: stringValue(ep.DatabaseName),
+	}
+}
+
+func convertConnection(conn types.Connection) Connection {
+	return Connection{
+		ReplicationInstanceARN:        stringValue(conn.ReplicationInstanceArn),
+		ReplicationInstanceIdentifier: stringValue(conn.ReplicationInstanceIdentifier),
+		EndpointARN:                   stringValue(conn.EndpointArn),
+		EndpointIdentifier:            stringValue(conn.EndpointIdentifier),
+		Status:                        stringValue(conn.Status),
+		LastFailureMessage:            stringValue(conn.LastFailureMessage),
+	}
+}
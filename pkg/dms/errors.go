@@ -0,0 +1,82 @@
+package dms
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/smithy-go"
+)
+
+// expiredCredentialCodes are API error codes returned for expired
+// credentials. Invalid keys (UnrecognizedClientException,
+// InvalidClientTokenId) are left out: logging in again does not fix them.
+var expiredCredentialCodes = []string{
+	"ExpiredToken",
+	"ExpiredTokenException",
+	"RequestExpired",
+}
+
+// throttlingCodes are API error codes returned when requests are throttled
+var throttlingCodes = []string{
+	"Throttling",
+	"ThrottlingException",
+	"ThrottledException",
+	"TooManyRequestsException",
+	"RequestLimitExceeded",
+}
+
+// expiredCredentialMessages are fragments of the errors returned by the
+// credential providers when an SSO session or cached token has expired
+var expiredCredentialMessages = []string{
+	"token has expired",
+	"refresh cached sso token failed",
+	"the sso session associated with this profile has expired",
+	"security token included in the request is expired",
+}
+
+// IsExpiredCredentials reports whether err was caused by expired credentials
+// that require logging in again
+func IsExpiredCredentials(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		for _, code := range expiredCredentialCodes {
+			if apiErr.ErrorCode() == code {
+				return true
+			}
+		}
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, fragment := range expiredCredentialMessages {
+		if strings.Contains(msg, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsThrottling reports whether err was caused by API rate limiting
+func IsThrottling(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		for _, code := range throttlingCodes {
+			if apiErr.ErrorCode() == code {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// LoginHint returns the command to refresh the credentials of a profile
+func LoginHint(profile string) string {
+	if profile == "" {
+		return "Credentials have expired; run 'aws sso login' (or refresh your credentials) and retry"
+	}
+	return fmt.Sprintf("Credentials have expired; run 'aws sso login --profile %s' (or refresh your credentials) and retry", profile)
+}
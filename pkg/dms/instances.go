@@ -0,0 +1,143 @@
+package dms
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/databasemigrationservice"
+	"github.com/aws/aws-sdk-go-v2/service/databasemigrationservice/types"
+)
+
+// ListReplicationInstances retrieves all replication instances
+func (c *Client) ListReplicationInstances(ctx context.Context) ([]ReplicationInstance, error) {
+	input := &databasemigrationservice.DescribeReplicationInstancesInput{}
+
+	var instances []ReplicationInstance
+	paginator := databasemigrationservice.NewDescribeReplicationInstancesPaginator(c.svc, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list replication instances: %w", err)
+		}
+
+		for _, inst := range output.ReplicationInstances {
+			instances = append(instances, convertInstance(inst))
+		}
+	}
+
+	return instances, nil
+}
+
+// DescribeReplicationInstance retrieves a single replication instance by ARN
+// or identifier
+func (c *Client) DescribeReplicationInstance(ctx context.Context, instance string) (*ReplicationInstance, error) {
+	filter := "replication-instance-id"
+	if strings.HasPrefix(instance, "arn:") {
+		filter = "replication-instance-arn"
+	}
+
+	input := &databasemigrationservice.DescribeReplicationInstancesInput{
+		Filters: []types.Filter{
+			{
+				Name:   stringPtr(filter),
+				Values: []string{instance},
+			},
+		},
+	}
+
+	output, err := c.svc.DescribeReplicationInstances(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe replication instance: %w", err)
+	}
+
+	if len(output.ReplicationInstances) == 0 {
+		return nil, fmt.Errorf("replication instance not found: %s", instance)
+	}
+
+	inst := convertInstance(output.ReplicationInstances[0])
+	return &inst, nil
+}
+
+// RebootReplicationInstance reboots a replication instance. With
+// forceFailover a Multi-AZ instance fails over to its standby instead of
+// rebooting in place.
+func (c *Client) RebootReplicationInstance(ctx context.Context, arn string, forceFailover bool) (*ReplicationInstance, error) {
+	input := &databasemigrationservice.RebootReplicationInstanceInput{
+		ReplicationInstanceArn: &arn,
+	}
+	if forceFailover {
+		input.ForceFailover = &forceFailover
+	}
+
+	output, err := c.svc.RebootReplicationInstance(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reboot replication instance: %w", err)
+	}
+
+	if output.ReplicationInstance == nil {
+		return nil, fmt.Errorf("no replication instance returned for %s", arn)
+	}
+
+	inst := convertInstance(*output.ReplicationInstance)
+	return &inst, nil
+}
+
+// TasksByInstance groups tasks by the ARN of the replication instance
+// hosting them
+func TasksByInstance(tasks []Task) map[string][]Task {
+	hosted := make(map[string][]Task)
+	for _, task := range tasks {
+		hosted[task.ReplicationInstanceARN] = append(hosted[task.ReplicationInstanceARN], task)
+	}
+	return hosted
+}
+
+func convertInstance(inst types.ReplicationInstance) ReplicationInstance {
+	return ReplicationInstance{
+		ARN:                       stringValue(inst.ReplicationInstanceArn),
+		Identifier:                stringValue(inst.ReplicationInstanceIdentifier),
+		Class:                     stringValue(inst.ReplicationInstanceClass),
+		Status:                    stringValue(inst.ReplicationInstanceStatus),
+		EngineVersion:             stringValue(inst.EngineVersion),
+		AvailabilityZone:          stringValue(inst.AvailabilityZone),
+		SecondaryAvailabilityZone: stringValue(inst.SecondaryAvailabilityZone),
+		AllocatedStorage:          inst.AllocatedStorage,
+		MultiAZ:                   inst.MultiAZ,
+		PubliclyAccessible:        inst.PubliclyAccessible,
+		MaintenanceWindow:         stringValue(inst.PreferredMaintenanceWindow),
+		PendingModifications:      convertPendingModifications(inst.PendingModifiedValues),
+		CreatedAt:                 inst.InstanceCreateTime,
+	}
+}
+
+// convertPendingModifications describes the changes waiting for the next
+// maintenance window, e.g. "class dms.r5.large"
+func convertPendingModifications(pending *types.ReplicationPendingModifiedValues) []string {
+	if pending == nil {
+		return nil
+	}
+
+	var mods []string
+	if pending.ReplicationInstanceClass != nil {
+		mods = append(mods, "class "+*pending.ReplicationInstanceClass)
+	}
+	if pending.EngineVersion != nil {
+		mods = append(mods, "engine "+*pending.EngineVersion)
+	}
+	if pending.AllocatedStorage != nil {
+		mods = append(mods, fmt.Sprintf("storage %d GB", *pending.AllocatedStorage))
+	}
+	if pending.MultiAZ != nil {
+		if *pending.MultiAZ {
+			mods = append(mods, "enable Multi-AZ")
+		} else {
+			mods = append(mods, "disable Multi-AZ")
+		}
+	}
+	if pending.NetworkType != nil {
+		mods = append(mods, "network "+*pending.NetworkType)
+	}
+	return mods
+}
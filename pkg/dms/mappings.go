@@ -0,0 +1,258 @@
+package dms
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Table mapping rule types
+const (
+	RuleTypeSelection      = "selection"
+	RuleTypeTransformation = "transformation"
+	RuleTypeTableSettings  = "table-settings"
+)
+
+// objectLocatorOrder lists object locator keys in the order they narrow
+// down an object; unknown keys sort after these
+var objectLocatorOrder = []string{"schema-name", "table-name", "column-name", "index-tablespace-name", "table-tablespace-name", "data-type"}
+
+// KeyValue is a named scalar value of a mapping rule
+type KeyValue struct {
+	Key   string
+	Value string
+}
+
+// MappingFilter is a source filter of a selection rule
+type MappingFilter struct {
+	Type       string
+	Column     string
+	Conditions []string
+}
+
+// MappingRule is one rule of a task's table mappings
+type MappingRule struct {
+	Type          string
+	ID            string
+	Name          string
+	Action        string
+	Target        string
+	ObjectLocator []KeyValue
+	Filters       []MappingFilter
+	Value         string
+
+	// Settings holds the remaining fields, e.g. parallel-load or lob-settings
+	// of table-settings rules, as compact JSON values
+	Settings []KeyValue
+}
+
+// TableMappings is the parsed table mappings document of a task
+type TableMappings struct {
+	Rules []MappingRule
+}
+
+// RulesOfType returns the rules with the given rule-type
+func (tm *TableMappings) RulesOfType(ruleType string) []MappingRule {
+	var rules []MappingRule
+	for _, r := range tm.Rules {
+		if r.Type == ruleType {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+// ParseTableMappings parses the table mappings JSON of a task
+func ParseTableMappings(s string) (*TableMappings, error) {
+	var doc struct {
+		Rules []map[string]json.RawMessage `json:"rules"`
+	}
+	if err := json.Unmarshal([]byte(s), &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse table mappings: %w", err)
+	}
+
+	tm := &TableMappings{}
+	for _, raw := range doc.Rules {
+		rule := MappingRule{
+			Type:   rawScalar(raw["rule-type"]),
+			ID:     rawScalar(raw["rule-id"]),
+			Name:   rawScalar(raw["rule-name"]),
+			Action: rawScalar(raw["rule-action"]),
+			Target: rawScalar(raw["rule-target"]),
+			Value:  rawScalar(raw["value"]),
+		}
+
+		if loc, ok := raw["object-locator"]; ok {
+			var fields map[string]json.RawMessage
+			if err := json.Unmarshal(loc, &fields); err == nil {
+				for k, v := range fields {
+					rule.ObjectLocator = append(rule.ObjectLocator, KeyValue{k, rawScalar(v)})
+				}
+				sortObjectLocator(rule.ObjectLocator)
+			}
+		}
+
+		if filters, ok := raw["filters"]; ok {
+			rule.Filters = parseMappingFilters(filters)
+		}
+
+		for k, v := range raw {
+			switch k {
+			case "rule-type", "rule-id", "rule-name", "rule-action", "rule-target", "value", "object-locator", "filters":
+				continue
+			}
+			rule.Settings = append(rule.Settings, KeyValue{k, rawScalar(v)})
+		}
+		sort.Slice(rule.Settings, func(i, j int) bool { return rule.Settings[i].Key < rule.Settings[j].Key })
+
+		tm.Rules = append(tm.Rules, rule)
+	}
+
+	return tm, nil
+}
+
+func parseMappingFilters(data json.RawMessage) []MappingFilter {
+	var raw []struct {
+		Type       string `json:"filter-type"`
+		Column     string `json:"column-name"`
+		Conditions []struct {
+			Operator   string          `json:"filter-operator"`
+			Value      json.RawMessage `json:"value"`
+			StartValue json.RawMessage `json:"start-value"`
+			EndValue   json.RawMessage `json:"end-value"`
+		} `json:"filter-conditions"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil
+	}
+
+	filters := make([]MappingFilter, 0, len(raw))
+	for _, f := range raw {
+		filter := MappingFilter{Type: f.Type, Column: f.Column}
+		for _, c := range f.Conditions {
+			cond := c.Operator
+			if c.Value != nil {
+				cond += " " + rawScalar(c.Value)
+			} else if c.StartValue != nil || c.EndValue != nil {
+				cond += fmt.Sprintf(" %s..%s", rawScalar(c.StartValue), rawScalar(c.EndValue))
+			}
+			filter.Conditions = append(filter.Conditions, cond)
+		}
+		filters = append(filters, filter)
+	}
+	return filters
+}
+
+// rawScalar renders a JSON value as plain text: strings unquoted, anything
+// else as compact JSON
+func rawScalar(v json.RawMessage) string {
+	if v == nil {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(v, &s); err == nil {
+		return s
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, v); err != nil {
+		return string(v)
+	}
+	return buf.String()
+}
+
+func sortObjectLocator(kvs []KeyValue) {
+	rank := func(k string) int {
+		for i, key := range objectLocatorOrder {
+			if key == k {
+				return i
+			}
+		}
+		return len(objectLocatorOrder)
+	}
+	sort.Slice(kvs, func(i, j int) bool {
+		ri, rj := rank(kvs[i].Key), rank(kvs[j].Key)
+		if ri != rj {
+			return ri < rj
+		}
+		return kvs[i].Key < kvs[j].Key
+	})
+}
+
+// PrettyJSON indents a JSON document for display
+func PrettyJSON(s string) (string, error) {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(strings.TrimSpace(s)), "", "  "); err != nil {
+		return "", fmt.Errorf("failed to format JSON: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// SelectionRule is a selection rule to build table mappings from
+type SelectionRule struct {
+	Schema string
+	Table  string
+	Action string // "include" or "exclude"
+}
+
+// BuildTableMappings renders selection rules as a table mappings document,
+// numbering the rules in order
+func BuildTableMappings(rules []SelectionRule) (string, error) {
+	type objectLocator struct {
+		Schema string `json:"schema-name"`
+		Table  string `json:"table-name"`
+	}
+	type rule struct {
+		Type          string        `json:"rule-type"`
+		ID            string        `json:"rule-id"`
+		Name          string        `json:"rule-name"`
+		ObjectLocator objectLocator `json:"object-locator"`
+		Action        string        `json:"rule-action"`
+	}
+
+	doc := struct {
+		Rules []rule `json:"rules"`
+	}{Rules: make([]rule, len(rules))}
+
+	for i, r := range rules {
+		if r.Action != "include" && r.Action != "exclude" {
+			return "", fmt.Errorf("invalid rule action %q", r.Action)
+		}
+		if r.Schema == "" || r.Table == "" {
+			return "", fmt.Errorf("rule %d needs a schema and table name", i+1)
+		}
+		id := fmt.Sprintf("%d", i+1)
+		doc.Rules[i] = rule{
+			Type:          RuleTypeSelection,
+			ID:            id,
+			Name:          id,
+			ObjectLocator: objectLocator{Schema: r.Schema, Table: r.Table},
+			Action:        r.Action,
+		}
+	}
+
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return "", fmt.Errorf("failed to build table mappings: %w", err)
+	}
+	return string(out), nil
+}
+
+// ParseSelectionRule parses a "schema.table" pattern, where a missing table
+// name selects every table of the schema
+func ParseSelectionRule(pattern, action string) (SelectionRule, error) {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return SelectionRule{}, fmt.Errorf("empty pattern, expected schema.table")
+	}
+
+	schema, table, ok := strings.Cut(pattern, ".")
+	if !ok {
+		table = "%"
+	}
+	if schema == "" || table == "" {
+		return SelectionRule{}, fmt.Errorf("invalid pattern %q, expected schema.table", pattern)
+	}
+	return SelectionRule{Schema: schema, Table: table, Action: action}, nil
+}
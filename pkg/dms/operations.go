@@ -3,7 +3,9 @@ package dms
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/databasemigrationservice"
 	"github.com/aws/aws-sdk-go-v2/service/databasemigrationservice/types"
@@ -54,10 +56,42 @@ func (c *Client) DescribeTask(ctx context.Context, arn string) (*Task, error) {
 	return &task, nil
 }
 
+// ListTaskTags retrieves the tags of several tasks with one request, keyed
+// by task ARN and tag key
+func (c *Client) ListTaskTags(ctx context.Context, arns []string) (map[string]map[string]string, error) {
+	tags := make(map[string]map[string]string, len(arns))
+	if len(arns) == 0 {
+		return tags, nil
+	}
+
+	output, err := c.svc.ListTagsForResource(ctx, &databasemigrationservice.ListTagsForResourceInput{
+		ResourceArnList: arns,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task tags: %w", err)
+	}
+
+	for _, tag := range output.TagList {
+		arn := stringValue(tag.ResourceArn)
+		if tags[arn] == nil {
+			tags[arn] = make(map[string]string)
+		}
+		tags[arn][stringValue(tag.Key)] = stringValue(tag.Value)
+	}
+	return tags, nil
+}
+
 // GetTableStatistics retrieves table statistics for a specific task
 func (c *Client) GetTableStatistics(ctx context.Context, arn string) ([]TableStatistic, error) {
+	return c.GetTableStatisticsFiltered(ctx, arn, TableStatsFilter{})
+}
+
+// GetTableStatisticsFiltered retrieves the statistics of the tables of a task
+// that pass the filter, leaving the filtering to DMS
+func (c *Client) GetTableStatisticsFiltered(ctx context.Context, arn string, filter TableStatsFilter) ([]TableStatistic, error) {
 	input := &databasemigrationservice.DescribeTableStatisticsInput{
 		ReplicationTaskArn: stringPtr(arn),
+		Filters:            filter.apiFilters(),
 	}
 
 	var stats []TableStatistic
@@ -77,11 +111,47 @@ func (c *Client) GetTableStatistics(ctx context.Context, arn string) ([]TableSta
 	return stats, nil
 }
 
-// StartTask starts a DMS replication task
-func (c *Client) StartTask(ctx context.Context, arn string, startType types.StartReplicationTaskTypeValue) error {
+// GetTableStatisticsBatch retrieves table statistics for multiple tasks in parallel
+func (c *Client) GetTableStatisticsBatch(ctx context.Context, arns []string) []TaskTableStatistics {
+	return c.GetTableStatisticsBatchFiltered(ctx, arns, TableStatsFilter{})
+}
+
+// GetTableStatisticsBatchFiltered retrieves the statistics of the tables
+// matching filter for multiple tasks in parallel
+func (c *Client) GetTableStatisticsBatchFiltered(ctx context.Context, arns []string, filter TableStatsFilter) []TaskTableStatistics {
+	var wg sync.WaitGroup
+	results := make([]TaskTableStatistics, len(arns))
+
+	for i, arn := range arns {
+		wg.Add(1)
+		go func(index int, taskARN string) {
+			defer wg.Done()
+
+			stats, err := c.GetTableStatisticsFiltered(ctx, taskARN, filter)
+			results[index] = TaskTableStatistics{
+				TaskARN: taskARN,
+				Stats:   stats,
+				Error:   err,
+			}
+		}(i, arn)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// StartTask starts a DMS replication task, positioning CDC as given by cdc
+func (c *Client) StartTask(ctx context.Context, arn string, startType types.StartReplicationTaskTypeValue, cdc CDCOptions) error {
 	input := &databasemigrationservice.StartReplicationTaskInput{
 		ReplicationTaskArn:       stringPtr(arn),
 		StartReplicationTaskType: startType,
+		CdcStartTime:             cdc.StartTime,
+	}
+	if cdc.StartPosition != "" {
+		input.CdcStartPosition = stringPtr(cdc.StartPosition)
+	}
+	if cdc.StopPosition != "" {
+		input.CdcStopPosition = stringPtr(cdc.StopPosition)
 	}
 
 	_, err := c.svc.StartReplicationTask(ctx, input)
@@ -106,8 +176,82 @@ func (c *Client) StopTask(ctx context.Context, arn string) error {
 	return nil
 }
 
-// StartTasks starts multiple tasks in parallel
-func (c *Client) StartTasks(ctx context.Context, arns []string, startType types.StartReplicationTaskTypeValue) []TaskOperation {
+// ModifyTaskSettings replaces the settings of a task. DMS only modifies
+// tasks that are not running.
+func (c *Client) ModifyTaskSettings(ctx context.Context, arn, settings string) error {
+	input := &databasemigrationservice.ModifyReplicationTaskInput{
+		ReplicationTaskArn:      stringPtr(arn),
+		ReplicationTaskSettings: stringPtr(settings),
+	}
+
+	_, err := c.svc.ModifyReplicationTask(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to modify task: %w", err)
+	}
+
+	return nil
+}
+
+// WaitForTaskStatus polls a task until it reaches one of the given statuses
+// or ctx is done
+func (c *Client) WaitForTaskStatus(ctx context.Context, arn string, interval time.Duration, statuses ...string) (*Task, error) {
+	return c.waitForTask(ctx, arn, interval, func(task *Task) bool {
+		return hasStatus(task, statuses...)
+	})
+}
+
+// WaitForTaskSettings polls a task until the settings patch it was just sent
+// has been applied or ctx is done. DMS may keep reporting the old status for
+// a moment, so the task is done once it is stopped, ready or failed and
+// either shows the patched settings or grace has passed.
+func (c *Client) WaitForTaskSettings(ctx context.Context, arn string, interval time.Duration, patch string, grace time.Duration) (*Task, error) {
+	deadline := time.Now().Add(grace)
+	return c.waitForTask(ctx, arn, interval, func(task *Task) bool {
+		if !hasStatus(task, "stopped", "ready", "failed") {
+			return false
+		}
+		applied, err := SettingsApplied(task.Settings, patch)
+		return (err == nil && applied) || !time.Now().Before(deadline)
+	})
+}
+
+// hasStatus reports whether a task has one of the given statuses
+func hasStatus(task *Task, statuses ...string) bool {
+	for _, s := range statuses {
+		if strings.EqualFold(task.Status, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// waitForTask polls a task until done accepts it or ctx is done
+func (c *Client) waitForTask(ctx context.Context, arn string, interval time.Duration, done func(task *Task) bool) (*Task, error) {
+	for {
+		task, err := c.DescribeTask(ctx, arn)
+		if err != nil {
+			return nil, err
+		}
+		if done(task) {
+			return task, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("task %s is still %s: %w", task.Name, task.Status, ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
+
+// StartTasks starts multiple tasks in parallel with the same CDC options
+func (c *Client) StartTasks(ctx context.Context, arns []string, startType types.StartReplicationTaskTypeValue, cdc CDCOptions) []TaskOperation {
+	return c.StartTasksAt(ctx, arns, startType, func(string) CDCOptions { return cdc })
+}
+
+// StartTasksAt starts multiple tasks in parallel with the CDC options cdc
+// returns for each task, e.g. to resume each from its own checkpoint
+func (c *Client) StartTasksAt(ctx context.Context, arns []string, startType types.StartReplicationTaskTypeValue, cdc func(arn string) CDCOptions) []TaskOperation {
 	var wg sync.WaitGroup
 	results := make([]TaskOperation, len(arns))
 
@@ -116,7 +260,7 @@ func (c *Client) StartTasks(ctx context.Context, arns []string, startType types.
 		go func(index int, taskARN string) {
 			defer wg.Done()
 
-			err := c.StartTask(ctx, taskARN, startType)
+			err := c.StartTask(ctx, taskARN, startType, cdc(taskARN))
 			results[index] = TaskOperation{
 				TaskARN: taskARN,
 				Success: err == nil,
@@ -165,7 +309,7 @@ func (c *Client) RestartTask(ctx context.Context, arn string, startType types.St
 	// For now, we'll just start it immediately
 
 	// Then start it
-	if err := c.StartTask(ctx, arn, startType); err != nil {
+	if err := c.StartTask(ctx, arn, startType, CDCOptions{}); err != nil {
 		return fmt.Errorf("failed to start task during restart: %w", err)
 	}
 
@@ -208,22 +352,35 @@ func convertTask(task types.ReplicationTask) Task {
 		TargetEndpointARN:      stringValue(task.TargetEndpointArn),
 		MigrationType:          string(task.MigrationType),
 		TableMappings:          stringValue(task.TableMappings),
+		Settings:               stringValue(task.ReplicationTaskSettings),
+		TaskData:               stringValue(task.TaskData),
 		CreatedAt:              task.ReplicationTaskCreationDate,
 		StartedAt:              task.ReplicationTaskStartDate,
-		StoppedAt:              nil, // AWS SDK doesn't provide a stopped timestamp
 		LastFailureMessage:     stringValue(task.LastFailureMessage),
+		StopReason:             stringValue(task.StopReason),
+		RecoveryCheckpoint:     stringValue(task.RecoveryCheckpoint),
+		CdcStartPosition:       stringValue(task.CdcStartPosition),
+		CdcStopPosition:        stringValue(task.CdcStopPosition),
+
+		TargetReplicationInstanceARN: stringValue(task.TargetReplicationInstanceArn),
 	}
 
-	if task.ReplicationTaskStats != nil {
+	if stats := task.ReplicationTaskStats; stats != nil {
 		t.ReplicationTaskStats = &TaskStats{
-			FullLoadProgressPercent: task.ReplicationTaskStats.FullLoadProgressPercent,
-			ElapsedTimeMillis:       task.ReplicationTaskStats.ElapsedTimeMillis,
-			TablesLoaded:            task.ReplicationTaskStats.TablesLoaded,
-			TablesLoading:           task.ReplicationTaskStats.TablesLoading,
-			TablesQueued:            task.ReplicationTaskStats.TablesQueued,
-			TablesErrored:           task.ReplicationTaskStats.TablesErrored,
-			StopReason:              stringValue(task.StopReason),
+			FullLoadProgressPercent: stats.FullLoadProgressPercent,
+			ElapsedTimeMillis:       stats.ElapsedTimeMillis,
+			TablesLoaded:            stats.TablesLoaded,
+			TablesLoading:           stats.TablesLoading,
+			TablesQueued:            stats.TablesQueued,
+			TablesErrored:           stats.TablesErrored,
+			StartDate:               stats.StartDate,
+			StopDate:                stats.StopDate,
+			FreshStartDate:          stats.FreshStartDate,
+			FullLoadStartDate:       stats.FullLoadStartDate,
+			FullLoadFinishDate:      stats.FullLoadFinishDate,
 		}
+		// DMS only reports when a task stopped in its statistics
+		t.StoppedAt = stats.StopDate
 	}
 
 	return t
@@ -231,16 +388,44 @@ func convertTask(task types.ReplicationTask) Task {
 
 func convertTableStatistic(stat types.TableStatistics) TableStatistic {
 	return TableStatistic{
-		SchemaName:      stringValue(stat.SchemaName),
-		TableName:       stringValue(stat.TableName),
-		Inserts:         stat.Inserts,
-		Deletes:         stat.Deletes,
-		Updates:         stat.Updates,
-		Ddls:            stat.Ddls,
-		FullLoadRows:    stat.FullLoadRows,
-		LastUtctime:     stat.LastUpdateTime,
-		ValidationState: stringValue(stat.ValidationState),
+		SchemaName:                   stringValue(stat.SchemaName),
+		TableName:                    stringValue(stat.TableName),
+		TableState:                   stringValue(stat.TableState),
+		Inserts:                      stat.Inserts,
+		Deletes:                      stat.Deletes,
+		Updates:                      stat.Updates,
+		Ddls:                         stat.Ddls,
+		AppliedInserts:               int64Value(stat.AppliedInserts),
+		AppliedUpdates:               int64Value(stat.AppliedUpdates),
+		AppliedDeletes:               int64Value(stat.AppliedDeletes),
+		AppliedDdls:                  int64Value(stat.AppliedDdls),
+		FullLoadRows:                 stat.FullLoadRows,
+		FullLoadErrorRows:            stat.FullLoadErrorRows,
+		FullLoadCondtnlChkFailedRows: stat.FullLoadCondtnlChkFailedRows,
+		FullLoadStartTime:            stat.FullLoadStartTime,
+		FullLoadEndTime:              stat.FullLoadEndTime,
+		FullLoadReloaded:             stat.FullLoadReloaded != nil && *stat.FullLoadReloaded,
+		LastUtctime:                  stat.LastUpdateTime,
+		ValidationState:              stringValue(stat.ValidationState),
+		ValidationStateDetails:       stringValue(stat.ValidationStateDetails),
+		ValidationPendingRecords:     stat.ValidationPendingRecords,
+		ValidationFailedRecords:      stat.ValidationFailedRecords,
+		ValidationSuspendedRecords:   stat.ValidationSuspendedRecords,
+	}
+}
+
+// apiFilters turns the filter into DescribeTableStatistics filters
+func (f TableStatsFilter) apiFilters() []types.Filter {
+	var filters []types.Filter
+	add := func(name string, values []string) {
+		if len(values) > 0 {
+			filters = append(filters, types.Filter{Name: stringPtr(name), Values: values})
+		}
 	}
+	add("schema-name", f.Schemas)
+	add("table-name", f.Tables)
+	add("table-state", f.States)
+	return filters
 }
 
 func getOperationMessage(operation string, err error) string {
@@ -0,0 +1,85 @@
+package dms
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ConnectionPair is a replication instance and an endpoint it connects to
+type ConnectionPair struct {
+	InstanceARN string
+	EndpointARN string
+}
+
+// ConnectionCheck is the outcome of testing one connection pair
+type ConnectionCheck struct {
+	ConnectionPair
+	Connection *Connection
+	Error      error
+}
+
+// Passed reports whether the connection test finished successfully
+func (c ConnectionCheck) Passed() bool {
+	return c.Error == nil && c.Connection != nil && c.Connection.Succeeded()
+}
+
+// ConnectionPairs returns the distinct (instance, source) and (instance,
+// target) pairs used by tasks, in the order they are first seen
+func ConnectionPairs(tasks []Task) []ConnectionPair {
+	seen := make(map[ConnectionPair]bool)
+	var pairs []ConnectionPair
+	for _, task := range tasks {
+		for _, ep := range []string{task.SourceEndpointARN, task.TargetEndpointARN} {
+			pair := ConnectionPair{InstanceARN: task.ReplicationInstanceARN, EndpointARN: ep}
+			if !seen[pair] {
+				seen[pair] = true
+				pairs = append(pairs, pair)
+			}
+		}
+	}
+	return pairs
+}
+
+// TestConnections tests every pair in parallel and waits for the results,
+// polling at interval until ctx is done
+func (c *Client) TestConnections(ctx context.Context, pairs []ConnectionPair, interval time.Duration) []ConnectionCheck {
+	var wg sync.WaitGroup
+	results := make([]ConnectionCheck, len(pairs))
+
+	for i, pair := range pairs {
+		wg.Add(1)
+		go func(index int, pair ConnectionPair) {
+			defer wg.Done()
+
+			check := ConnectionCheck{ConnectionPair: pair}
+			if _, err := c.TestConnection(ctx, pair.InstanceARN, pair.EndpointARN); err != nil {
+				check.Error = err
+			} else {
+				check.Connection, check.Error = c.WaitForConnection(ctx, pair.InstanceARN, pair.EndpointARN, interval)
+			}
+			results[index] = check
+		}(i, pair)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// FailedChecks returns the checks of a task's connection pairs that did not
+// pass
+func FailedChecks(task Task, checks []ConnectionCheck) []ConnectionCheck {
+	var failed []ConnectionCheck
+	for _, check := range checks {
+		if check.InstanceARN != task.ReplicationInstanceARN {
+			continue
+		}
+		if check.EndpointARN != task.SourceEndpointARN && check.EndpointARN != task.TargetEndpointARN {
+			continue
+		}
+		if !check.Passed() {
+			failed = append(failed, check)
+		}
+	}
+	return failed
+}
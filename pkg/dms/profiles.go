@@ -0,0 +1,129 @@
+package dms
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Regions lists the commercial AWS regions where DMS is available
+var Regions = []string{
+	"us-east-1",
+	"us-east-2",
+	"us-west-1",
+	"us-west-2",
+	"af-south-1",
+	"ap-east-1",
+	"ap-south-1",
+	"ap-south-2",
+	"ap-northeast-1",
+	"ap-northeast-2",
+	"ap-northeast-3",
+	"ap-southeast-1",
+	"ap-southeast-2",
+	"ap-southeast-3",
+	"ap-southeast-4",
+	"ca-central-1",
+	"ca-west-1",
+	"eu-central-1",
+	"eu-central-2",
+	"eu-north-1",
+	"eu-south-1",
+	"eu-south-2",
+	"eu-west-1",
+	"eu-west-2",
+	"eu-west-3",
+	"il-central-1",
+	"me-central-1",
+	"me-south-1",
+	"sa-east-1",
+}
+
+// ListProfiles returns the profile names defined in the shared AWS config and
+// credentials files, honoring AWS_CONFIG_FILE and AWS_SHARED_CREDENTIALS_FILE.
+// The default profile, when present, is listed first.
+func ListProfiles() ([]string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	configFile := os.Getenv("AWS_CONFIG_FILE")
+	if configFile == "" {
+		configFile = filepath.Join(home, ".aws", "config")
+	}
+	credentialsFile := os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
+	if credentialsFile == "" {
+		credentialsFile = filepath.Join(home, ".aws", "credentials")
+	}
+
+	seen := make(map[string]bool)
+	for _, f := range []struct {
+		path     string
+		isConfig bool
+	}{
+		{configFile, true},
+		{credentialsFile, false},
+	} {
+		names, err := readProfileSections(f.path, f.isConfig)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			seen[name] = true
+		}
+	}
+
+	profiles := make([]string, 0, len(seen))
+	for name := range seen {
+		if name != "default" {
+			profiles = append(profiles, name)
+		}
+	}
+	sort.Strings(profiles)
+	if seen["default"] {
+		profiles = append([]string{"default"}, profiles...)
+	}
+
+	return profiles, nil
+}
+
+// readProfileSections extracts profile names from the section headers of an
+// AWS shared config or credentials file. In the config file profiles other
+// than default are written as "[profile name]"; other section kinds such as
+// sso-session are ignored. A missing file has no profiles.
+func readProfileSections(path string, isConfig bool) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var names []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "[") || !strings.HasSuffix(line, "]") {
+			continue
+		}
+
+		section := strings.TrimSpace(line[1 : len(line)-1])
+		if isConfig && section != "default" {
+			name, ok := strings.CutPrefix(section, "profile ")
+			if !ok {
+				continue
+			}
+			section = strings.TrimSpace(name)
+		}
+		if section != "" {
+			names = append(names, section)
+		}
+	}
+
+	return names, scanner.Err()
+}
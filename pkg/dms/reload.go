@@ -0,0 +1,74 @@
+package dms
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/databasemigrationservice"
+	"github.com/aws/aws-sdk-go-v2/service/databasemigrationservice/types"
+)
+
+// ReloadMode selects what ReloadTables does with the tables
+type ReloadMode string
+
+const (
+	// ReloadData reloads the tables from the source, then validates them if
+	// validation is enabled for the task
+	ReloadData ReloadMode = "data-reload"
+
+	// ReloadValidateOnly revalidates the tables without reloading their data
+	ReloadValidateOnly ReloadMode = "validate-only"
+)
+
+// TableRef names a table of a task
+type TableRef struct {
+	Schema string
+	Table  string
+}
+
+// String returns the table as "schema.table"
+func (t TableRef) String() string {
+	return t.Schema + "." + t.Table
+}
+
+// TableRefOf returns the table a statistics row belongs to
+func TableRefOf(s TableStatistic) TableRef {
+	return TableRef{Schema: s.SchemaName, Table: s.TableName}
+}
+
+// ReloadTables reloads or revalidates individual tables of a running task,
+// leaving the rest of the task untouched
+func (c *Client) ReloadTables(ctx context.Context, taskARN string, tables []TableRef, mode ReloadMode) error {
+	if len(tables) == 0 {
+		return fmt.Errorf("no tables to reload")
+	}
+
+	var option types.ReloadOptionValue
+	switch mode {
+	case ReloadData:
+		option = types.ReloadOptionValueDataReload
+	case ReloadValidateOnly:
+		option = types.ReloadOptionValueValidateOnly
+	default:
+		return fmt.Errorf("invalid reload mode %q (use %s or %s)", mode, ReloadData, ReloadValidateOnly)
+	}
+
+	toReload := make([]types.TableToReload, len(tables))
+	for i, t := range tables {
+		toReload[i] = types.TableToReload{
+			SchemaName: stringPtr(t.Schema),
+			TableName:  stringPtr(t.Table),
+		}
+	}
+
+	input := &databasemigrationservice.ReloadTablesInput{
+		ReplicationTaskArn: &taskARN,
+		TablesToReload:     toReload,
+		ReloadOption:       option,
+	}
+
+	if _, err := c.svc.ReloadTables(ctx, input); err != nil {
+		return fmt.Errorf("failed to reload tables: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package dms
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxRefreshBackoff caps the delay between retries of a failing refresh
+	MaxRefreshBackoff = 2 * time.Minute
+
+	// quietRefreshes is the number of refreshes without changes before an
+	// adaptive scheduler starts slowing down
+	quietRefreshes = 3
+)
+
+// DefaultRefreshPolicy polls every 5 seconds, every 2 seconds while tasks are
+// starting or stopping, and slows down to once a minute when nothing changes
+var DefaultRefreshPolicy = RefreshPolicy{
+	Interval: 5 * time.Second,
+	Fast:     2 * time.Second,
+	Idle:     time.Minute,
+	Adaptive: true,
+}
+
+// RefreshPolicy configures how often watch-style views poll the API
+type RefreshPolicy struct {
+	// Interval is the regular delay between refreshes
+	Interval time.Duration
+
+	// Fast is the delay while a task is in a transitional state
+	Fast time.Duration
+
+	// Idle is the longest delay reached when nothing changes
+	Idle time.Duration
+
+	// Adaptive enables the fast and idle delays; otherwise Interval is used
+	Adaptive bool
+}
+
+// Validate checks that the delays are usable
+func (p RefreshPolicy) Validate() error {
+	if p.Interval < time.Second {
+		return fmt.Errorf("refresh interval must be at least 1s")
+	}
+	if p.Fast < time.Second {
+		return fmt.Errorf("fast refresh interval must be at least 1s")
+	}
+	if p.Idle < p.Interval {
+		return fmt.Errorf("idle refresh interval must not be shorter than the refresh interval")
+	}
+	return nil
+}
+
+// RefreshScheduler decides the delay before the next refresh from the
+// outcome of the previous ones
+type RefreshScheduler struct {
+	policy       RefreshPolicy
+	failures     int
+	quiet        int
+	transitional bool
+}
+
+// NewRefreshScheduler creates a scheduler for the given policy
+func NewRefreshScheduler(policy RefreshPolicy) *RefreshScheduler {
+	return &RefreshScheduler{policy: policy}
+}
+
+// Observe records a successful refresh. transitional reports whether a task
+// is starting, stopping or otherwise changing state; changed whether the
+// refreshed data differs from the previous refresh.
+func (s *RefreshScheduler) Observe(transitional, changed bool) {
+	s.failures = 0
+	s.transitional = transitional
+	if changed {
+		s.quiet = 0
+	} else {
+		s.quiet++
+	}
+}
+
+// Fail records a failed refresh
+func (s *RefreshScheduler) Fail() {
+	s.failures++
+}
+
+// Reset returns to the regular interval, e.g. after the user acted
+func (s *RefreshScheduler) Reset() {
+	s.quiet = 0
+}
+
+// Failures returns the number of consecutive failed refreshes
+func (s *RefreshScheduler) Failures() int {
+	return s.failures
+}
+
+// Next returns the delay before the next refresh
+func (s *RefreshScheduler) Next() time.Duration {
+	p := s.policy
+
+	if s.failures > 0 {
+		return doubled(p.Interval, s.failures, MaxRefreshBackoff)
+	}
+	if !p.Adaptive {
+		return p.Interval
+	}
+	if s.transitional {
+		return min(p.Fast, p.Interval)
+	}
+	if s.quiet > quietRefreshes {
+		return doubled(p.Interval, s.quiet-quietRefreshes, p.Idle)
+	}
+	return p.Interval
+}
+
+// Mode describes why the next delay differs from the regular interval:
+// "backoff", "fast", "idle" or "" for the regular interval
+func (s *RefreshScheduler) Mode() string {
+	switch {
+	case s.failures > 0:
+		return "backoff"
+	case !s.policy.Adaptive:
+		return ""
+	case s.transitional:
+		return "fast"
+	case s.quiet > quietRefreshes:
+		return "idle"
+	default:
+		return ""
+	}
+}
+
+// doubled doubles d n times, capped at limit
+func doubled(d time.Duration, n int, limit time.Duration) time.Duration {
+	for i := 0; i < n && d < limit; i++ {
+		d *= 2
+	}
+	return min(d, limit)
+}
+
+// IsTransitional reports whether a task status is a short-lived state that
+// is worth polling more often
+func IsTransitional(status string) bool {
+	switch strings.ToLower(status) {
+	case "starting", "stopping", "creating", "deleting", "modifying", "moving", "testing":
+		return true
+	default:
+		return false
+	}
+}
+
+// AnyTransitional reports whether any task is in a transitional state
+func AnyTransitional(tasks []Task) bool {
+	for _, t := range tasks {
+		if IsTransitional(t.Status) {
+			return true
+		}
+	}
+	return false
+}
+
+// TasksChanged reports whether the status or progress of any task differs
+// between two task lists
+func TasksChanged(prev, curr []Task) bool {
+	if len(prev) != len(curr) {
+		return true
+	}
+
+	old := make(map[string]Task, len(prev))
+	for _, t := range prev {
+		old[t.ARN] = t
+	}
+
+	for _, t := range curr {
+		p, ok := old[t.ARN]
+		if !ok || p.Status != t.Status {
+			return true
+		}
+		if !p.ReplicationTaskStats.Equal(t.ReplicationTaskStats) {
+			return true
+		}
+	}
+	return false
+}
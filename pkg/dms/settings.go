@@ -0,0 +1,218 @@
+package dms
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// taskSettings holds the task settings SettingsSummary reports on. Pointers
+// tell settings left to the DMS defaults apart from ones set to false.
+type taskSettings struct {
+	TargetMetadata struct {
+		SupportLobs        *bool
+		FullLobMode        *bool
+		LimitedSizeLobMode *bool
+		LobMaxSize         int
+		BatchApplyEnabled  *bool
+	}
+	FullLoadSettings struct {
+		TargetTablePrepMode string
+	}
+	Logging struct {
+		EnableLogging *bool
+	}
+	ValidationSettings struct {
+		EnableValidation *bool
+	}
+}
+
+// SettingsSummary describes the task settings that matter most at a glance:
+// LOB handling, logging, validation, batch apply and how target tables are
+// prepared, e.g. "limited LOB mode (32 KB)" or "logging off". Settings left
+// to the DMS defaults are not mentioned.
+func SettingsSummary(settings string) ([]string, error) {
+	if strings.TrimSpace(settings) == "" {
+		return nil, nil
+	}
+
+	var s taskSettings
+	if err := json.Unmarshal([]byte(settings), &s); err != nil {
+		return nil, fmt.Errorf("failed to parse task settings: %w", err)
+	}
+
+	var summary []string
+	tm := s.TargetMetadata
+	switch {
+	case tm.SupportLobs != nil && !*tm.SupportLobs:
+		summary = append(summary, "no LOBs")
+	case tm.FullLobMode != nil && *tm.FullLobMode:
+		summary = append(summary, "full LOB mode")
+	case tm.LimitedSizeLobMode != nil && *tm.LimitedSizeLobMode:
+		summary = append(summary, fmt.Sprintf("limited LOB mode (%d KB)", tm.LobMaxSize))
+	}
+	if tm.BatchApplyEnabled != nil && *tm.BatchApplyEnabled {
+		summary = append(summary, "batch apply")
+	}
+	if v := s.Logging.EnableLogging; v != nil {
+		summary = append(summary, "logging "+onOff(*v))
+	}
+	if v := s.ValidationSettings.EnableValidation; v != nil {
+		summary = append(summary, "validation "+onOff(*v))
+	}
+	if mode := s.FullLoadSettings.TargetTablePrepMode; mode != "" {
+		summary = append(summary, "target tables "+mode)
+	}
+	return summary, nil
+}
+
+func onOff(v bool) string {
+	if v {
+		return "on"
+	}
+	return "off"
+}
+
+// MergeSettings applies a JSON merge patch (RFC 7386) to task settings:
+// objects in the patch are merged member by member, null removes a member
+// and any other value replaces it. The result is indented.
+func MergeSettings(settings, patch string) (string, error) {
+	var doc, p any
+	if strings.TrimSpace(settings) != "" {
+		if err := json.Unmarshal([]byte(settings), &doc); err != nil {
+			return "", fmt.Errorf("failed to parse task settings: %w", err)
+		}
+	}
+	if err := json.Unmarshal([]byte(patch), &p); err != nil {
+		return "", fmt.Errorf("failed to parse settings patch: %w", err)
+	}
+	if _, ok := p.(map[string]any); !ok {
+		return "", fmt.Errorf("settings patch must be a JSON object")
+	}
+
+	merged, err := json.MarshalIndent(mergePatch(doc, p), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode task settings: %w", err)
+	}
+	return string(merged), nil
+}
+
+func mergePatch(doc, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	d, ok := doc.(map[string]any)
+	if !ok {
+		d = map[string]any{}
+	}
+	for key, value := range p {
+		if value == nil {
+			delete(d, key)
+		} else {
+			d[key] = mergePatch(d[key], value)
+		}
+	}
+	return d
+}
+
+// SettingsApplied reports whether task settings already contain everything a
+// JSON merge patch sets, i.e. whether applying it would change nothing
+func SettingsApplied(settings, patch string) (bool, error) {
+	merged, err := MergeSettings(settings, patch)
+	if err != nil {
+		return false, err
+	}
+	if strings.TrimSpace(settings) == "" {
+		settings = "{}"
+	}
+	changes, err := DiffSettings(settings, merged)
+	if err != nil {
+		return false, err
+	}
+	return len(changes) == 0, nil
+}
+
+// SettingsChange is a difference between two settings documents at a path
+// such as "Logging.LogComponents[0].Severity". Old is missing for added
+// settings and New for removed ones.
+type SettingsChange struct {
+	Path     string
+	Old, New any
+	HasOld   bool
+	HasNew   bool
+}
+
+// DiffSettings compares two settings documents member by member and returns
+// the differences ordered by path
+func DiffSettings(a, b string) ([]SettingsChange, error) {
+	var docA, docB any
+	if err := json.Unmarshal([]byte(a), &docA); err != nil {
+		return nil, fmt.Errorf("failed to parse task settings: %w", err)
+	}
+	if err := json.Unmarshal([]byte(b), &docB); err != nil {
+		return nil, fmt.Errorf("failed to parse task settings: %w", err)
+	}
+
+	var changes []SettingsChange
+	diffValues("", docA, docB, &changes)
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+func diffValues(path string, a, b any, changes *[]SettingsChange) {
+	switch va := a.(type) {
+	case map[string]any:
+		if vb, ok := b.(map[string]any); ok {
+			for key, value := range va {
+				if other, ok := vb[key]; ok {
+					diffValues(joinPath(path, key), value, other, changes)
+				} else {
+					*changes = append(*changes, SettingsChange{Path: joinPath(path, key), Old: value, HasOld: true})
+				}
+			}
+			for key, value := range vb {
+				if _, ok := va[key]; !ok {
+					*changes = append(*changes, SettingsChange{Path: joinPath(path, key), New: value, HasNew: true})
+				}
+			}
+			return
+		}
+	case []any:
+		if vb, ok := b.([]any); ok {
+			for i := 0; i < max(len(va), len(vb)); i++ {
+				itemPath := fmt.Sprintf("%s[%d]", path, i)
+				switch {
+				case i >= len(vb):
+					*changes = append(*changes, SettingsChange{Path: itemPath, Old: va[i], HasOld: true})
+				case i >= len(va):
+					*changes = append(*changes, SettingsChange{Path: itemPath, New: vb[i], HasNew: true})
+				default:
+					diffValues(itemPath, va[i], vb[i], changes)
+				}
+			}
+			return
+		}
+	}
+	if !reflect.DeepEqual(a, b) {
+		*changes = append(*changes, SettingsChange{Path: path, Old: a, New: b, HasOld: true, HasNew: true})
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// FormatSettingsValue renders a settings value as compact JSON
+func FormatSettingsValue(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
@@ -0,0 +1,299 @@
+package dms
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TaskTable is the statistics of a table together with the task loading it
+type TaskTable struct {
+	TaskARN           string
+	TaskName          string
+	TargetEndpointARN string
+	TableStatistic
+}
+
+// tableTextFields are the fields table conditions compare as text
+var tableTextFields = map[string]func(TaskTable) string{
+	"task":       func(t TaskTable) string { return t.TaskName },
+	"schema":     func(t TaskTable) string { return t.SchemaName },
+	"table":      func(t TaskTable) string { return t.TableName },
+	"state":      func(t TaskTable) string { return t.TableState },
+	"validation": func(t TaskTable) string { return t.ValidationState },
+}
+
+// tableNumberFields are the counters table conditions and sorting compare
+// as numbers
+var tableNumberFields = map[string]func(TableStatistic) int64{
+	"rows":      func(s TableStatistic) int64 { return s.FullLoadRows },
+	"errors":    func(s TableStatistic) int64 { return s.FullLoadErrorRows + s.FullLoadCondtnlChkFailedRows },
+	"inserts":   func(s TableStatistic) int64 { return s.Inserts },
+	"updates":   func(s TableStatistic) int64 { return s.Updates },
+	"deletes":   func(s TableStatistic) int64 { return s.Deletes },
+	"ddls":      func(s TableStatistic) int64 { return s.Ddls },
+	"changes":   func(s TableStatistic) int64 { return s.Inserts + s.Updates + s.Deletes },
+	"applied":   func(s TableStatistic) int64 { return s.AppliedInserts + s.AppliedUpdates + s.AppliedDeletes },
+	"pending":   func(s TableStatistic) int64 { return s.ValidationPendingRecords },
+	"failed":    func(s TableStatistic) int64 { return s.ValidationFailedRecords },
+	"suspended": func(s TableStatistic) int64 { return s.ValidationSuspendedRecords },
+}
+
+// TableFields lists the field names table conditions and sorting accept
+func TableFields() []string {
+	fields := make([]string, 0, len(tableTextFields)+len(tableNumberFields))
+	for name := range tableTextFields {
+		fields = append(fields, name)
+	}
+	for name := range tableNumberFields {
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// TableCondition is a condition on one field of a table, such as
+// "state=error" or "rows>1000000"
+type TableCondition struct {
+	Field string
+	Op    string
+	Value string
+	num   int64
+}
+
+// tableOps are the condition operators, longest first so "==" and "!=" aren't
+// read as "="
+var tableOps = []string{"==", "!=", ">=", "<=", "=", ">", "<"}
+
+// ParseTableCondition parses a condition of the form field<op>value. Text
+// fields take = and != and match case-insensitive substrings, or == and match
+// the whole value; counters take =, ==, !=, >, >=, < and <=.
+func ParseTableCondition(s string) (TableCondition, error) {
+	for _, op := range tableOps {
+		field, value, ok := strings.Cut(s, op)
+		if !ok {
+			continue
+		}
+		c := TableCondition{Field: strings.ToLower(strings.TrimSpace(field)), Op: op, Value: strings.TrimSpace(value)}
+		if _, ok := tableTextFields[c.Field]; ok {
+			if op != "=" && op != "==" && op != "!=" {
+				return c, fmt.Errorf("invalid condition %q: %s is compared with =, == or !=", s, c.Field)
+			}
+			return c, nil
+		}
+		if _, ok := tableNumberFields[c.Field]; ok {
+			n, err := strconv.ParseInt(c.Value, 10, 64)
+			if err != nil {
+				return c, fmt.Errorf("invalid condition %q: %s is a number", s, c.Field)
+			}
+			c.num = n
+			return c, nil
+		}
+		return c, fmt.Errorf("invalid condition %q: unknown field %q (use %s)", s, c.Field, strings.Join(TableFields(), ", "))
+	}
+	return TableCondition{}, fmt.Errorf("invalid condition %q (use field=value, e.g. state=error or rows>1000)", s)
+}
+
+// Matches reports whether a table meets the condition
+func (c TableCondition) Matches(t TaskTable) bool {
+	if text, ok := tableTextFields[c.Field]; ok {
+		if c.Op == "==" {
+			return strings.EqualFold(text(t), c.Value)
+		}
+		contains := strings.Contains(strings.ToLower(text(t)), strings.ToLower(c.Value))
+		return contains == (c.Op == "=")
+	}
+
+	n := tableNumberFields[c.Field](t.TableStatistic)
+	switch c.Op {
+	case "=", "==":
+		return n == c.num
+	case "!=":
+		return n != c.num
+	case ">":
+		return n > c.num
+	case ">=":
+		return n >= c.num
+	case "<":
+		return n < c.num
+	default:
+		return n <= c.num
+	}
+}
+
+// ServerTableFilter returns the conditions DMS can apply itself when fetching
+// table statistics: whole-value (==) matches of schema, table and state. The
+// conditions still have to be checked on the fetched tables, as several
+// values for one field are fetched as alternatives.
+func ServerTableFilter(conditions []TableCondition) TableStatsFilter {
+	var f TableStatsFilter
+	for _, c := range conditions {
+		if c.Op != "==" {
+			continue
+		}
+		switch c.Field {
+		case "schema":
+			f.Schemas = append(f.Schemas, c.Value)
+		case "table":
+			f.Tables = append(f.Tables, c.Value)
+		case "state":
+			f.States = append(f.States, c.Value)
+		}
+	}
+	return f
+}
+
+// ValidateTableSort checks a sort field; "" keeps the task and table order
+func ValidateTableSort(by string) error {
+	if by == "" {
+		return nil
+	}
+	if _, ok := tableTextFields[by]; ok {
+		return nil
+	}
+	if _, ok := tableNumberFields[by]; ok {
+		return nil
+	}
+	return fmt.Errorf("invalid sort field %q (use %s)", by, strings.Join(TableFields(), ", "))
+}
+
+// SortTaskTables sorts tables by a field: counters largest first, text
+// fields alphabetically. Ties, and an empty field, fall back to task,
+// schema and table name.
+func SortTaskTables(tables []TaskTable, by string) {
+	byName := func(a, b TaskTable) bool {
+		if a.TaskName != b.TaskName {
+			return a.TaskName < b.TaskName
+		}
+		if a.SchemaName != b.SchemaName {
+			return a.SchemaName < b.SchemaName
+		}
+		return a.TableName < b.TableName
+	}
+
+	sort.SliceStable(tables, func(i, j int) bool {
+		a, b := tables[i], tables[j]
+		if num, ok := tableNumberFields[by]; ok {
+			if x, y := num(a.TableStatistic), num(b.TableStatistic); x != y {
+				return x > y
+			}
+		} else if text, ok := tableTextFields[by]; ok {
+			if x, y := strings.ToLower(text(a)), strings.ToLower(text(b)); x != y {
+				return x < y
+			}
+		}
+		return byName(a, b)
+	})
+}
+
+// SchemaSummary totals the tables of a schema across tasks
+type SchemaSummary struct {
+	Schema        string
+	Tasks         []string // names of the tasks loading the schema
+	Tables        int
+	ErroredTables int
+	Totals        TableStatistic // summed counters
+}
+
+// SummarizeBySchema totals tables per schema, ordered by a sort field as in
+// SortTaskTables; text fields other than the schema keep the schema order
+func SummarizeBySchema(tables []TaskTable, by string) []SchemaSummary {
+	bySchema := make(map[string]*SchemaSummary)
+	for _, t := range tables {
+		sum, ok := bySchema[t.SchemaName]
+		if !ok {
+			sum = &SchemaSummary{Schema: t.SchemaName}
+			bySchema[t.SchemaName] = sum
+		}
+		sum.Tables++
+		if t.HasErrors() {
+			sum.ErroredTables++
+		}
+		if !slices.Contains(sum.Tasks, t.TaskName) {
+			sum.Tasks = append(sum.Tasks, t.TaskName)
+		}
+		addTableCounters(&sum.Totals, t.TableStatistic)
+	}
+
+	summaries := make([]SchemaSummary, 0, len(bySchema))
+	for _, sum := range bySchema {
+		sort.Strings(sum.Tasks)
+		summaries = append(summaries, *sum)
+	}
+	sort.SliceStable(summaries, func(i, j int) bool {
+		a, b := summaries[i], summaries[j]
+		if num, ok := tableNumberFields[by]; ok {
+			if x, y := num(a.Totals), num(b.Totals); x != y {
+				return x > y
+			}
+		}
+		return a.Schema < b.Schema
+	})
+	return summaries
+}
+
+// addTableCounters adds the counters of s to total
+func addTableCounters(total *TableStatistic, s TableStatistic) {
+	total.Inserts += s.Inserts
+	total.Updates += s.Updates
+	total.Deletes += s.Deletes
+	total.Ddls += s.Ddls
+	total.AppliedInserts += s.AppliedInserts
+	total.AppliedUpdates += s.AppliedUpdates
+	total.AppliedDeletes += s.AppliedDeletes
+	total.AppliedDdls += s.AppliedDdls
+	total.FullLoadRows += s.FullLoadRows
+	total.FullLoadErrorRows += s.FullLoadErrorRows
+	total.FullLoadCondtnlChkFailedRows += s.FullLoadCondtnlChkFailedRows
+	total.ValidationPendingRecords += s.ValidationPendingRecords
+	total.ValidationFailedRecords += s.ValidationFailedRecords
+	total.ValidationSuspendedRecords += s.ValidationSuspendedRecords
+}
+
+// TableOverlap is a table loaded into the same target endpoint by more than
+// one task
+type TableOverlap struct {
+	SchemaName        string
+	TableName         string
+	TargetEndpointARN string
+	Tasks             []string // task names
+}
+
+// FindOverlaps returns the tables that more than one task loads into the
+// same target endpoint, by endpoint and table name. Tables are compared by
+// their source names, so tasks renaming tables on the way can be missed.
+func FindOverlaps(tables []TaskTable) []TableOverlap {
+	type key struct{ endpoint, schema, table string }
+	byTable := make(map[key]*TableOverlap)
+	var order []key
+	for _, t := range tables {
+		k := key{t.TargetEndpointARN, strings.ToLower(t.SchemaName), strings.ToLower(t.TableName)}
+		o, ok := byTable[k]
+		if !ok {
+			o = &TableOverlap{SchemaName: t.SchemaName, TableName: t.TableName, TargetEndpointARN: t.TargetEndpointARN}
+			byTable[k] = o
+			order = append(order, k)
+		}
+		if !slices.Contains(o.Tasks, t.TaskName) {
+			o.Tasks = append(o.Tasks, t.TaskName)
+		}
+	}
+
+	var overlaps []TableOverlap
+	for _, k := range order {
+		if o := byTable[k]; len(o.Tasks) > 1 {
+			sort.Strings(o.Tasks)
+			overlaps = append(overlaps, *o)
+		}
+	}
+	sort.SliceStable(overlaps, func(i, j int) bool {
+		a, b := overlaps[i], overlaps[j]
+		if a.SchemaName != b.SchemaName {
+			return a.SchemaName < b.SchemaName
+		}
+		return a.TableName < b.TableName
+	})
+	return overlaps
+}
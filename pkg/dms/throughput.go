@@ -0,0 +1,180 @@
+package dms
+
+import "time"
+
+const (
+	// DefaultThroughputWindow is the number of samples kept per task or table
+	DefaultThroughputWindow = 30
+
+	// minSampleInterval drops samples taken too close together, which would
+	// otherwise produce noisy rates when the same stats are polled twice
+	minSampleInterval = time.Second
+)
+
+// ThroughputSample is a snapshot of cumulative counters at a point in time
+type ThroughputSample struct {
+	Time    time.Time
+	Rows    int64 // Full load rows
+	Changes int64 // Inserts + updates + deletes
+}
+
+// Rate is the throughput between two successive samples
+type Rate struct {
+	RowsPerSec    float64
+	ChangesPerSec float64
+}
+
+// Total returns the combined full load and change rate
+func (r Rate) Total() float64 {
+	return r.RowsPerSec + r.ChangesPerSec
+}
+
+// ThroughputWindow keeps a rolling window of samples for one task or table
+type ThroughputWindow struct {
+	samples []ThroughputSample
+	size    int
+}
+
+// NewThroughputWindow creates a window holding at most size samples
+func NewThroughputWindow(size int) *ThroughputWindow {
+	if size < 2 {
+		size = 2
+	}
+	return &ThroughputWindow{size: size}
+}
+
+// Add appends a sample, evicting the oldest when the window is full
+func (w *ThroughputWindow) Add(s ThroughputSample) {
+	if n := len(w.samples); n > 0 && s.Time.Sub(w.samples[n-1].Time) < minSampleInterval {
+		return
+	}
+	w.samples = append(w.samples, s)
+	if len(w.samples) > w.size {
+		w.samples = w.samples[len(w.samples)-w.size:]
+	}
+}
+
+// Rates returns the rate between each pair of successive samples, oldest
+// first. Counters that went backwards (e.g. after a reload) count as zero.
+// A nil window has no rates.
+func (w *ThroughputWindow) Rates() []Rate {
+	if w == nil || len(w.samples) < 2 {
+		return nil
+	}
+
+	rates := make([]Rate, 0, len(w.samples)-1)
+	for i := 1; i < len(w.samples); i++ {
+		prev, curr := w.samples[i-1], w.samples[i]
+		secs := curr.Time.Sub(prev.Time).Seconds()
+		rates = append(rates, Rate{
+			RowsPerSec:    perSecond(curr.Rows-prev.Rows, secs),
+			ChangesPerSec: perSecond(curr.Changes-prev.Changes, secs),
+		})
+	}
+	return rates
+}
+
+// Latest returns the most recent rate, or false until two samples exist
+func (w *ThroughputWindow) Latest() (Rate, bool) {
+	rates := w.Rates()
+	if len(rates) == 0 {
+		return Rate{}, false
+	}
+	return rates[len(rates)-1], true
+}
+
+// Totals returns the combined rate of each interval, suitable for a sparkline
+func (w *ThroughputWindow) Totals() []float64 {
+	rates := w.Rates()
+	totals := make([]float64, len(rates))
+	for i, r := range rates {
+		totals[i] = r.Total()
+	}
+	return totals
+}
+
+// Stalled reports whether throughput dropped to zero after having been
+// non-zero earlier in the window
+func (w *ThroughputWindow) Stalled() bool {
+	totals := w.Totals()
+	if len(totals) < 2 || totals[len(totals)-1] > 0 {
+		return false
+	}
+	for _, t := range totals[:len(totals)-1] {
+		if t > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ThroughputTracker keeps throughput windows per task and per table
+type ThroughputTracker struct {
+	size   int
+	tasks  map[string]*ThroughputWindow
+	tables map[string]map[string]*ThroughputWindow
+}
+
+// NewThroughputTracker creates a tracker keeping size samples per window
+func NewThroughputTracker(size int) *ThroughputTracker {
+	return &ThroughputTracker{
+		size:   size,
+		tasks:  make(map[string]*ThroughputWindow),
+		tables: make(map[string]map[string]*ThroughputWindow),
+	}
+}
+
+// Record adds a sample for a task and each of its tables from one poll of
+// its table statistics
+func (t *ThroughputTracker) Record(taskARN string, at time.Time, stats []TableStatistic) {
+	tables, ok := t.tables[taskARN]
+	if !ok {
+		tables = make(map[string]*ThroughputWindow)
+		t.tables[taskARN] = tables
+	}
+
+	var total ThroughputSample
+	total.Time = at
+	for _, s := range stats {
+		sample := ThroughputSample{
+			Time:    at,
+			Rows:    s.FullLoadRows,
+			Changes: s.Inserts + s.Updates + s.Deletes,
+		}
+		total.Rows += sample.Rows
+		total.Changes += sample.Changes
+
+		key := s.SchemaName + "." + s.TableName
+		w, ok := tables[key]
+		if !ok {
+			w = NewThroughputWindow(t.size)
+			tables[key] = w
+		}
+		w.Add(sample)
+	}
+
+	w, ok := t.tasks[taskARN]
+	if !ok {
+		w = NewThroughputWindow(t.size)
+		t.tasks[taskARN] = w
+	}
+	w.Add(total)
+}
+
+// Task returns the window for a task, or nil if it has never been sampled
+func (t *ThroughputTracker) Task(taskARN string) *ThroughputWindow {
+	return t.tasks[taskARN]
+}
+
+// Table returns the window for a table ("schema.table") of a task, or nil if
+// it has never been sampled
+func (t *ThroughputTracker) Table(taskARN, table string) *ThroughputWindow {
+	return t.tables[taskARN][table]
+}
+
+func perSecond(delta int64, secs float64) float64 {
+	if delta <= 0 || secs <= 0 {
+		return 0
+	}
+	return float64(delta) / secs
+}
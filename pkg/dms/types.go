@@ -1,6 +1,10 @@
 package dms
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 // Task represents a DMS replication task
 type Task struct {
@@ -12,11 +16,41 @@ type Task struct {
 	TargetEndpointARN      string
 	MigrationType          string
 	TableMappings          string
+	Settings               string // ReplicationTaskSettings JSON
+	TaskData               string // supplemental data some endpoints need, JSON
 	CreatedAt              *time.Time
 	StartedAt              *time.Time
 	StoppedAt              *time.Time
 	LastFailureMessage     string
+	StopReason             string
+	RecoveryCheckpoint     string // where CDC resumes, empty before CDC has run
+	CdcStartPosition       string
+	CdcStopPosition        string
 	ReplicationTaskStats   *TaskStats
+
+	// TargetReplicationInstanceARN is the instance the task is being moved
+	// to, while a move is pending
+	TargetReplicationInstanceARN string
+}
+
+// IsMoving reports whether the task is being moved to another replication
+// instance
+func (t Task) IsMoving() bool {
+	return t.TargetReplicationInstanceARN != ""
+}
+
+// RunDuration returns how long the task ran from its last start until it
+// stopped, or until now if it is still running. ok is false if the task has
+// never started.
+func (t Task) RunDuration(now time.Time) (d time.Duration, ok bool) {
+	if t.StartedAt == nil {
+		return 0, false
+	}
+	end := now
+	if t.StoppedAt != nil && t.StoppedAt.After(*t.StartedAt) {
+		end = *t.StoppedAt
+	}
+	return end.Sub(*t.StartedAt), true
 }
 
 // TaskStats contains statistics about a replication task
@@ -27,20 +61,136 @@ type TaskStats struct {
 	TablesLoading           int32
 	TablesQueued            int32
 	TablesErrored           int32
-	StopReason              string
+	StartDate               *time.Time
+	StopDate                *time.Time
+	FreshStartDate          *time.Time // last start that reloaded the target
+	FullLoadStartDate       *time.Time
+	FullLoadFinishDate      *time.Time
+}
+
+// Equal reports whether two task stats have the same counters and dates.
+// Either may be nil.
+func (s *TaskStats) Equal(o *TaskStats) bool {
+	if s == nil || o == nil {
+		return s == o
+	}
+	return s.FullLoadProgressPercent == o.FullLoadProgressPercent &&
+		s.ElapsedTimeMillis == o.ElapsedTimeMillis &&
+		s.TablesLoaded == o.TablesLoaded &&
+		s.TablesLoading == o.TablesLoading &&
+		s.TablesQueued == o.TablesQueued &&
+		s.TablesErrored == o.TablesErrored &&
+		timesEqual(s.StartDate, o.StartDate) &&
+		timesEqual(s.StopDate, o.StopDate) &&
+		timesEqual(s.FreshStartDate, o.FreshStartDate) &&
+		timesEqual(s.FullLoadStartDate, o.FullLoadStartDate) &&
+		timesEqual(s.FullLoadFinishDate, o.FullLoadFinishDate)
+}
+
+// timesEqual compares optional times by value
+func timesEqual(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// FullLoadDuration returns how long the full load took, or how long it has
+// been running if it hasn't finished. ok is false if it never started.
+func (s TaskStats) FullLoadDuration(now time.Time) (d time.Duration, ok bool) {
+	if s.FullLoadStartDate == nil {
+		return 0, false
+	}
+	end := now
+	if s.FullLoadFinishDate != nil && s.FullLoadFinishDate.After(*s.FullLoadStartDate) {
+		end = *s.FullLoadFinishDate
+	}
+	return end.Sub(*s.FullLoadStartDate), true
 }
 
 // TableStatistic represents statistics for a single table in a replication task
 type TableStatistic struct {
-	SchemaName      string
-	TableName       string
-	Inserts         int64
-	Deletes         int64
-	Updates         int64
-	Ddls            int64
-	FullLoadRows    int64
-	LastUtctime     *time.Time
-	ValidationState string
+	SchemaName string
+	TableName  string
+	TableState string // e.g. "Table completed", "Table error"
+	Inserts    int64
+	Deletes    int64
+	Updates    int64
+	Ddls       int64
+
+	// Changes applied to the target during CDC, as opposed to the changes
+	// captured from the source counted above
+	AppliedInserts int64
+	AppliedUpdates int64
+	AppliedDeletes int64
+	AppliedDdls    int64
+
+	FullLoadRows                 int64
+	FullLoadErrorRows            int64
+	FullLoadCondtnlChkFailedRows int64
+	FullLoadStartTime            *time.Time
+	FullLoadEndTime              *time.Time
+	FullLoadReloaded             bool
+	LastUtctime                  *time.Time
+
+	ValidationState            string
+	ValidationStateDetails     string
+	ValidationPendingRecords   int64
+	ValidationFailedRecords    int64
+	ValidationSuspendedRecords int64
+}
+
+// HasErrors reports whether the table is in error or rows failed to load
+func (s TableStatistic) HasErrors() bool {
+	return strings.EqualFold(s.TableState, "Table error") || s.FullLoadErrorRows > 0 ||
+		s.FullLoadCondtnlChkFailedRows > 0 || s.ValidationFailedRecords > 0
+}
+
+// FullLoadDuration returns how long the full load of the table took, or how
+// long it has been running if it hasn't finished. ok is false if it never
+// started.
+func (s TableStatistic) FullLoadDuration(now time.Time) (d time.Duration, ok bool) {
+	if s.FullLoadStartTime == nil {
+		return 0, false
+	}
+	end := now
+	if s.FullLoadEndTime != nil && s.FullLoadEndTime.After(*s.FullLoadStartTime) {
+		end = *s.FullLoadEndTime
+	}
+	return end.Sub(*s.FullLoadStartTime), true
+}
+
+// ValidationCounts lists the non-zero validation record counters, e.g.
+// "12 pending, 1 failed", or returns "" if there are none
+func (s TableStatistic) ValidationCounts() string {
+	var parts []string
+	for _, c := range []struct {
+		n    int64
+		name string
+	}{
+		{s.ValidationPendingRecords, "pending"},
+		{s.ValidationFailedRecords, "failed"},
+		{s.ValidationSuspendedRecords, "suspended"},
+	} {
+		if c.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", c.n, c.name))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// TableStatsFilter narrows table statistics down on the server. Each field
+// holds the values to accept, any of which may match; empty fields accept
+// all tables.
+type TableStatsFilter struct {
+	Schemas []string // schema-name
+	Tables  []string // table-name
+	States  []string // table-state, e.g. "Table error"
+}
+
+// IsZero reports whether the filter accepts all tables
+func (f TableStatsFilter) IsZero() bool {
+	return len(f.Schemas) == 0 && len(f.Tables) == 0 && len(f.States) == 0
 }
 
 // TaskOperation represents the result of an operation on a task
@@ -50,3 +200,80 @@ type TaskOperation struct {
 	Error   error
 	Message string
 }
+
+// TaskTableStatistics holds the table statistics fetched for one task as part
+// of a batch
+type TaskTableStatistics struct {
+	TaskARN string
+	Stats   []TableStatistic
+	Error   error
+}
+
+// ReplicationInstance represents a DMS replication instance
+type ReplicationInstance struct {
+	ARN                       string
+	Identifier                string
+	Class                     string
+	Status                    string
+	EngineVersion             string
+	AvailabilityZone          string
+	SecondaryAvailabilityZone string
+	AllocatedStorage          int32
+	MultiAZ                   bool
+	PubliclyAccessible        bool
+	MaintenanceWindow         string
+	PendingModifications      []string
+	CreatedAt                 *time.Time
+}
+
+// Endpoint represents a DMS source or target endpoint
+type Endpoint struct {
+	ARN          string
+	Identifier   string
+	Type         string // "source" or "target"
+	Engine       string
+	Status       string
+	ServerName   string
+	Port         int32
+	DatabaseName string
+}
+
+// IsSource reports whether the endpoint can be used as a task source
+func (e Endpoint) IsSource() bool {
+	return strings.EqualFold(e.Type, "source")
+}
+
+// IsTarget reports whether the endpoint can be used as a task target
+func (e Endpoint) IsTarget() bool {
+	return strings.EqualFold(e.Type, "target")
+}
+
+// Label returns the endpoint's identifier followed by its engine, e.g.
+// "orders-db (postgres)"
+func (e Endpoint) Label() string {
+	if e.Engine == "" {
+		return e.Identifier
+	}
+	return fmt.Sprintf("%s (%s)", e.Identifier, e.Engine)
+}
+
+// Connection is the result of a connection test between a replication
+// instance and an endpoint
+type Connection struct {
+	ReplicationInstanceARN        string
+	ReplicationInstanceIdentifier string
+	EndpointARN                   string
+	EndpointIdentifier            string
+	Status                        string // "testing", "successful" or "failed"
+	LastFailureMessage            string
+}
+
+// IsFinal reports whether the connection test has finished
+func (c Connection) IsFinal() bool {
+	return c.Status != "testing"
+}
+
+// Succeeded reports whether the connection test finished successfully
+func (c Connection) Succeeded() bool {
+	return c.Status == "successful"
+}
@@ -1,6 +1,9 @@
 package dms
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // FormatElapsedTime converts milliseconds to human-readable format
 func FormatElapsedTime(millis int64) string {
@@ -39,3 +42,58 @@ func FormatElapsedTime(millis int64) string {
 
 	return fmt.Sprintf("%ds", seconds)
 }
+
+// FormatDuration formats a duration like FormatElapsedTime, e.g. "2h 15m"
+func FormatDuration(d time.Duration) string {
+	return FormatElapsedTime(d.Milliseconds())
+}
+
+// sparkBlocks are the bar glyphs used by Sparkline, lowest to highest
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a bar chart of at most width characters, using
+// the most recent values when there are more than fit. Values are scaled to
+// the largest value shown; an all-zero series renders as a flat baseline.
+func Sparkline(values []float64, width int) string {
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+
+	peak := 0.0
+	for _, v := range values {
+		if v > peak {
+			peak = v
+		}
+	}
+
+	out := make([]rune, 0, width)
+	for i := len(values); i < width; i++ {
+		out = append(out, ' ')
+	}
+	for _, v := range values {
+		idx := 0
+		if peak > 0 && v > 0 {
+			idx = int(v / peak * float64(len(sparkBlocks)-1))
+			if idx == 0 {
+				// Any activity at all should be visible above the baseline
+				idx = 1
+			}
+		}
+		out = append(out, sparkBlocks[idx])
+	}
+	return string(out)
+}
+
+// FormatRate formats a per-second rate compactly, e.g. "850/s" or "1.2k/s"
+func FormatRate(perSec float64) string {
+	switch {
+	case perSec >= 1_000_000:
+		return fmt.Sprintf("%.1fM/s", perSec/1_000_000)
+	case perSec >= 1_000:
+		return fmt.Sprintf("%.1fk/s", perSec/1_000)
+	case perSec >= 10 || perSec == 0:
+		return fmt.Sprintf("%.0f/s", perSec)
+	default:
+		return fmt.Sprintf("%.1f/s", perSec)
+	}
+}
@@ -0,0 +1,162 @@
+package dms
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// jsonField is a member of a JSON object, kept in document order
+type jsonField struct {
+	key   string
+	value any
+}
+
+// JSONToYAML renders a JSON document as YAML, keeping the order of object
+// members. Strings that YAML would read as another type are quoted.
+func JSONToYAML(doc string) (string, error) {
+	dec := json.NewDecoder(strings.NewReader(doc))
+	dec.UseNumber()
+	value, err := decodeOrdered(dec)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	var sb strings.Builder
+	switch v := value.(type) {
+	case []jsonField:
+		writeYAMLObject(&sb, v, 0)
+	case []any:
+		writeYAMLArray(&sb, v, 0)
+	default:
+		sb.WriteString(yamlScalar(v) + "\n")
+	}
+	return sb.String(), nil
+}
+
+// decodeOrdered decodes the next JSON value, objects as []jsonField
+func decodeOrdered(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		fields := []jsonField{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, jsonField{key: key.(string), value: value})
+		}
+		_, err = dec.Token() // closing brace
+		return fields, err
+	case json.Delim('['):
+		items := []any{}
+		for dec.More() {
+			item, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		_, err = dec.Token() // closing bracket
+		return items, err
+	default:
+		return tok, nil
+	}
+}
+
+func writeYAMLObject(sb *strings.Builder, fields []jsonField, indent int) {
+	pad := strings.Repeat(" ", indent)
+	for _, f := range fields {
+		sb.WriteString(pad + yamlString(f.key) + ":")
+		writeYAMLValue(sb, f.value, indent)
+	}
+}
+
+func writeYAMLArray(sb *strings.Builder, items []any, indent int) {
+	pad := strings.Repeat(" ", indent)
+	for _, item := range items {
+		switch v := item.(type) {
+		case []jsonField:
+			if len(v) == 0 {
+				sb.WriteString(pad + "- {}\n")
+				continue
+			}
+			// The first member goes on the dash line, the rest below it
+			var nested strings.Builder
+			writeYAMLObject(&nested, v, indent+2)
+			sb.WriteString(pad + "- " + strings.TrimPrefix(nested.String(), pad+"  "))
+		case []any:
+			if len(v) == 0 {
+				sb.WriteString(pad + "- []\n")
+				continue
+			}
+			sb.WriteString(pad + "-\n")
+			writeYAMLArray(sb, v, indent+2)
+		default:
+			sb.WriteString(pad + "- " + yamlScalar(v) + "\n")
+		}
+	}
+}
+
+// writeYAMLValue writes the value of an object member after its key
+func writeYAMLValue(sb *strings.Builder, value any, indent int) {
+	switch v := value.(type) {
+	case []jsonField:
+		if len(v) == 0 {
+			sb.WriteString(" {}\n")
+			return
+		}
+		sb.WriteString("\n")
+		writeYAMLObject(sb, v, indent+2)
+	case []any:
+		if len(v) == 0 {
+			sb.WriteString(" []\n")
+			return
+		}
+		sb.WriteString("\n")
+		writeYAMLArray(sb, v, indent+2)
+	default:
+		sb.WriteString(" " + yamlScalar(v) + "\n")
+	}
+}
+
+// yamlPlainUnsafe matches strings YAML would read as something other than
+// the string itself, or could not read at all, unquoted
+var yamlPlainUnsafe = regexp.MustCompile(`^$|^[\s\-?:,\[\]{}#&*!|>'"%@` + "`" + `]|\s$|: |\s#|[\n\t]|^(?i:true|false|yes|no|on|off|null|~|y|n)$|^[-+]?(\.?[0-9]|\.inf|\.nan)`)
+
+func yamlScalar(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	case json.Number:
+		return v.String()
+	case string:
+		return yamlString(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// yamlString quotes a string only when YAML needs it; a JSON string is a
+// valid YAML double-quoted string
+func yamlString(s string) string {
+	if !yamlPlainUnsafe.MatchString(s) && !strings.HasSuffix(s, ":") {
+		return s
+	}
+	quoted, _ := json.Marshal(s)
+	return string(quoted)
+}
@@ -7,23 +7,33 @@ import (
 	"log"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 )
 
 // Mock DMS data structures
 type MockTask struct {
-	ReplicationTaskArn          string     `json:"ReplicationTaskArn"`
-	ReplicationTaskIdentifier   string     `json:"ReplicationTaskIdentifier"`
-	Status                      string     `json:"Status"`
-	ReplicationInstanceArn      string     `json:"ReplicationInstanceArn"`
-	SourceEndpointArn           string     `json:"SourceEndpointArn"`
-	TargetEndpointArn           string     `json:"TargetEndpointArn"`
-	MigrationType               string     `json:"MigrationType"`
-	TableMappings               string     `json:"TableMappings"`
-	ReplicationTaskCreationDate int64      `json:"ReplicationTaskCreationDate,omitempty"`
-	ReplicationTaskStartDate    int64      `json:"ReplicationTaskStartDate,omitempty"`
-	LastFailureMessage          string     `json:"LastFailureMessage,omitempty"`
-	ReplicationTaskStats        *TaskStats `json:"ReplicationTaskStats,omitempty"`
+	ReplicationTaskArn           string     `json:"ReplicationTaskArn"`
+	ReplicationTaskIdentifier    string     `json:"ReplicationTaskIdentifier"`
+	Status                       string     `json:"Status"`
+	ReplicationInstanceArn       string     `json:"ReplicationInstanceArn"`
+	SourceEndpointArn            string     `json:"SourceEndpointArn"`
+	TargetEndpointArn            string     `json:"TargetEndpointArn"`
+	MigrationType                string     `json:"MigrationType"`
+	TableMappings                string     `json:"TableMappings"`
+	ReplicationTaskSettings      string     `json:"ReplicationTaskSettings,omitempty"`
+	TaskData                     string     `json:"TaskData,omitempty"`
+	TargetReplicationInstanceArn string     `json:"TargetReplicationInstanceArn,omitempty"`
+	ReplicationTaskCreationDate  int64      `json:"ReplicationTaskCreationDate,omitempty"`
+	ReplicationTaskStartDate     int64      `json:"ReplicationTaskStartDate,omitempty"`
+	LastFailureMessage           string     `json:"LastFailureMessage,omitempty"`
+	StopReason                   string     `json:"StopReason,omitempty"`
+	RecoveryCheckpoint           string     `json:"RecoveryCheckpoint,omitempty"`
+	CdcStartPosition             string     `json:"CdcStartPosition,omitempty"`
+	CdcStopPosition              string     `json:"CdcStopPosition,omitempty"`
+	ReplicationTaskStats         *TaskStats `json:"ReplicationTaskStats,omitempty"`
+
+	modifying bool // a modification is in progress, even before Status shows it
 }
 
 type TaskStats struct {
@@ -33,8 +43,16 @@ type TaskStats struct {
 	TablesLoading           int32 `json:"TablesLoading"`
 	TablesQueued            int32 `json:"TablesQueued"`
 	TablesErrored           int32 `json:"TablesErrored"`
+	StartDate               int64 `json:"StartDate,omitempty"`
+	StopDate                int64 `json:"StopDate,omitempty"`
+	FreshStartDate          int64 `json:"FreshStartDate,omitempty"`
+	FullLoadStartDate       int64 `json:"FullLoadStartDate,omitempty"`
+	FullLoadFinishDate      int64 `json:"FullLoadFinishDate,omitempty"`
 }
 
+// tasksMu guards tasks, whose statuses also change in the background
+var tasksMu sync.Mutex
+
 // Global mock data
 var tasks = map[string]*MockTask{
 	"mock-task-1": {
@@ -46,6 +64,7 @@ var tasks = map[string]*MockTask{
 		TargetEndpointArn:           "arn:aws:dms:us-east-1:123456789012:endpoint:mock-target",
 		MigrationType:               "full-load",
 		TableMappings:               `{"rules":[{"rule-type":"selection","rule-id":"1","rule-name":"1","object-locator":{"schema-name":"%","table-name":"%"},"rule-action":"include"}]}`,
+		ReplicationTaskSettings:     `{"TargetMetadata":{"SupportLobs":true,"FullLobMode":false,"LimitedSizeLobMode":true,"LobMaxSize":32},"FullLoadSettings":{"TargetTablePrepMode":"DO_NOTHING","MaxFullLoadSubTasks":8},"Logging":{"EnableLogging":true}}`,
 		ReplicationTaskCreationDate: epoch(time.Now().Add(-24 * time.Hour)),
 		ReplicationTaskStartDate:    epoch(time.Now().Add(-2 * time.Hour)),
 		ReplicationTaskStats: &TaskStats{
@@ -55,6 +74,9 @@ var tasks = map[string]*MockTask{
 			TablesLoading:           3,
 			TablesQueued:            2,
 			TablesErrored:           0,
+			StartDate:               epoch(time.Now().Add(-2 * time.Hour)),
+			FreshStartDate:          epoch(time.Now().Add(-2 * time.Hour)),
+			FullLoadStartDate:       epoch(time.Now().Add(-2 * time.Hour)),
 		},
 	},
 	"mock-task-2": {
@@ -65,27 +87,42 @@ var tasks = map[string]*MockTask{
 		SourceEndpointArn:           "arn:aws:dms:us-east-1:123456789012:endpoint:mock-source",
 		TargetEndpointArn:           "arn:aws:dms:us-east-1:123456789012:endpoint:mock-target",
 		MigrationType:               "full-load-and-cdc",
+		RecoveryCheckpoint:          "checkpoint:V1#34#00000001/7A1B2C30:1#0#0#*#0#121",
 		TableMappings:               `{"rules":[{"rule-type":"selection","rule-id":"1","rule-name":"1","object-locator":{"schema-name":"public","table-name":"%"},"rule-action":"include"}]}`,
+		ReplicationTaskSettings:     `{"TargetMetadata":{"SupportLobs":true,"FullLobMode":false,"LimitedSizeLobMode":true,"LobMaxSize":64,"BatchApplyEnabled":true},"FullLoadSettings":{"TargetTablePrepMode":"TRUNCATE_BEFORE_LOAD"},"Logging":{"EnableLogging":true},"ValidationSettings":{"EnableValidation":true,"ThreadCount":5}}`,
 		ReplicationTaskCreationDate: epoch(time.Now().Add(-48 * time.Hour)),
+		ReplicationTaskStartDate:    epoch(time.Now().Add(-47 * time.Hour)),
+		StopReason:                  "STOP_REASON_USER_REQUESTED",
 		ReplicationTaskStats: &TaskStats{
 			FullLoadProgressPercent: 100,
+			ElapsedTimeMillis:       158400000,
 			TablesLoaded:            25,
 			TablesLoading:           0,
 			TablesQueued:            0,
 			TablesErrored:           0,
+			StartDate:               epoch(time.Now().Add(-47 * time.Hour)),
+			StopDate:                epoch(time.Now().Add(-3 * time.Hour)),
+			FreshStartDate:          epoch(time.Now().Add(-47 * time.Hour)),
+			FullLoadStartDate:       epoch(time.Now().Add(-47 * time.Hour)),
+			FullLoadFinishDate:      epoch(time.Now().Add(-45*time.Hour - 20*time.Minute)),
 		},
 	},
 	"mock-task-3": {
-		ReplicationTaskArn:          "arn:aws:dms:us-east-1:123456789012:task:mock-task-3",
-		ReplicationTaskIdentifier:   "mock-task-3",
-		Status:                      "failed",
-		ReplicationInstanceArn:      "arn:aws:dms:us-east-1:123456789012:rep:mock-instance",
-		SourceEndpointArn:           "arn:aws:dms:us-east-1:123456789012:endpoint:mock-source",
-		TargetEndpointArn:           "arn:aws:dms:us-east-1:123456789012:endpoint:mock-target",
-		MigrationType:               "cdc",
-		TableMappings:               `{"rules":[{"rule-type":"selection","rule-id":"1","rule-name":"1","object-locator":{"schema-name":"app","table-name":"%"},"rule-action":"include"}]}`,
-		ReplicationTaskCreationDate: epoch(time.Now().Add(-72 * time.Hour)),
-		LastFailureMessage:          "Connection timeout to source database",
+		ReplicationTaskArn:           "arn:aws:dms:us-east-1:123456789012:task:mock-task-3",
+		ReplicationTaskIdentifier:    "mock-task-3",
+		Status:                       "failed",
+		ReplicationInstanceArn:       "arn:aws:dms:us-east-1:123456789012:rep:mock-instance-ha",
+		SourceEndpointArn:            "arn:aws:dms:us-east-1:123456789012:endpoint:mock-source",
+		TargetEndpointArn:            "arn:aws:dms:us-east-1:123456789012:endpoint:mock-target",
+		MigrationType:                "cdc",
+		RecoveryCheckpoint:           "checkpoint:V1#27#00000001/7A0F1E88:1#0#0#*#0#93",
+		TableMappings:                `{"rules":[{"rule-type":"selection","rule-id":"1","rule-name":"1","object-locator":{"schema-name":"app","table-name":"%"},"rule-action":"include"}]}`,
+		ReplicationTaskSettings:      `{"TargetMetadata":{"SupportLobs":true,"FullLobMode":true,"LobChunkSize":64},"FullLoadSettings":{"TargetTablePrepMode":"DROP_AND_CREATE"},"Logging":{"EnableLogging":false},"ValidationSettings":{"EnableValidation":false}}`,
+		TaskData:                     `{"TargetSchema":"app_replica"}`,
+		TargetReplicationInstanceArn: "arn:aws:dms:us-east-1:123456789012:rep:mock-instance",
+		ReplicationTaskCreationDate:  epoch(time.Now().Add(-72 * time.Hour)),
+		LastFailureMessage:           "Connection timeout to source database",
+		StopReason:                   "FATAL_ERROR",
 		ReplicationTaskStats: &TaskStats{
 			FullLoadProgressPercent: 45,
 			TablesLoaded:            10,
@@ -107,19 +144,163 @@ type MockTableStat struct {
 	FullLoadRows    int64  `json:"FullLoadRows"`
 	LastUpdateTime  int64  `json:"LastUpdateTime"`
 	ValidationState string `json:"ValidationState"`
+
+	TableState                   string `json:"TableState,omitempty"`
+	AppliedInserts               int64  `json:"AppliedInserts"`
+	AppliedUpdates               int64  `json:"AppliedUpdates"`
+	AppliedDeletes               int64  `json:"AppliedDeletes"`
+	AppliedDdls                  int64  `json:"AppliedDdls"`
+	FullLoadErrorRows            int64  `json:"FullLoadErrorRows"`
+	FullLoadCondtnlChkFailedRows int64  `json:"FullLoadCondtnlChkFailedRows"`
+	FullLoadStartTime            int64  `json:"FullLoadStartTime,omitempty"`
+	FullLoadEndTime              int64  `json:"FullLoadEndTime,omitempty"`
+	FullLoadReloaded             bool   `json:"FullLoadReloaded"`
+	ValidationPendingRecords     int64  `json:"ValidationPendingRecords"`
+	ValidationFailedRecords      int64  `json:"ValidationFailedRecords"`
+	ValidationSuspendedRecords   int64  `json:"ValidationSuspendedRecords"`
+	ValidationStateDetails       string `json:"ValidationStateDetails,omitempty"`
 }
 
 var tableStats = map[string][]MockTableStat{
 	"arn:aws:dms:us-east-1:123456789012:task:mock-task-1": {
-		{SchemaName: "public", TableName: "users", Inserts: 100, Deletes: 5, Updates: 20, FullLoadRows: 1000, LastUpdateTime: epoch(time.Now()), ValidationState: "Validated"},
-		{SchemaName: "public", TableName: "orders", Inserts: 500, Deletes: 10, Updates: 50, FullLoadRows: 5000, LastUpdateTime: epoch(time.Now()), ValidationState: "Validated"},
-		{SchemaName: "public", TableName: "products", Inserts: 50, Deletes: 0, Updates: 10, FullLoadRows: 500, LastUpdateTime: epoch(time.Now()), ValidationState: "Validated"},
+		{SchemaName: "public", TableName: "users", Inserts: 100, Deletes: 5, Updates: 20, FullLoadRows: 1000, LastUpdateTime: epoch(time.Now()), ValidationState: "Validated",
+			TableState: "Table completed", AppliedInserts: 100, AppliedUpdates: 20, AppliedDeletes: 5,
+			FullLoadStartTime: epoch(time.Now().Add(-2 * time.Hour)), FullLoadEndTime: epoch(time.Now().Add(-110 * time.Minute))},
+		{SchemaName: "public", TableName: "orders", Inserts: 500, Deletes: 10, Updates: 50, FullLoadRows: 5000, LastUpdateTime: epoch(time.Now()), ValidationState: "Pending records",
+			TableState: "Table completed", AppliedInserts: 480, AppliedUpdates: 50, AppliedDeletes: 10, ValidationPendingRecords: 20,
+			FullLoadStartTime: epoch(time.Now().Add(-2 * time.Hour)), FullLoadEndTime: epoch(time.Now().Add(-95 * time.Minute))},
+		{SchemaName: "public", TableName: "products", Inserts: 50, Deletes: 0, Updates: 10, FullLoadRows: 500, LastUpdateTime: epoch(time.Now()), ValidationState: "Validated",
+			TableState: "Table completed", AppliedInserts: 50, AppliedUpdates: 10, FullLoadReloaded: true,
+			FullLoadStartTime: epoch(time.Now().Add(-30 * time.Minute)), FullLoadEndTime: epoch(time.Now().Add(-29 * time.Minute))},
 	},
 	"arn:aws:dms:us-east-1:123456789012:task:mock-task-2": {
-		{SchemaName: "public", TableName: "inventory", Inserts: 10, Deletes: 0, Updates: 5, FullLoadRows: 100, LastUpdateTime: epoch(time.Now()), ValidationState: "Pending"},
+		{SchemaName: "public", TableName: "inventory", Inserts: 10, Deletes: 0, Updates: 5, FullLoadRows: 100, LastUpdateTime: epoch(time.Now()), ValidationState: "Pending",
+			TableState: "Table completed", AppliedInserts: 10, AppliedUpdates: 5,
+			FullLoadStartTime: epoch(time.Now().Add(-3 * time.Hour)), FullLoadEndTime: epoch(time.Now().Add(-170 * time.Minute))},
+		{SchemaName: "billing", TableName: "invoices", FullLoadRows: 740, LastUpdateTime: epoch(time.Now()), ValidationState: "Mismatched records",
+			TableState: "Table error", FullLoadErrorRows: 12, FullLoadCondtnlChkFailedRows: 3, ValidationFailedRecords: 4, ValidationSuspendedRecords: 1,
+			ValidationStateDetails: "Mismatched records found for 4 rows",
+			FullLoadStartTime:      epoch(time.Now().Add(-40 * time.Minute)), FullLoadEndTime: epoch(time.Now().Add(-25 * time.Minute))},
+	},
+	"arn:aws:dms:us-east-1:123456789012:task:mock-task-3": {
+		{SchemaName: "public", TableName: "orders", FullLoadRows: 2200, LastUpdateTime: epoch(time.Now()), ValidationState: "Not enabled",
+			TableState: "Table loading", FullLoadStartTime: epoch(time.Now().Add(-12 * time.Minute))},
+		{SchemaName: "billing", TableName: "payments", Inserts: 42, Updates: 7, FullLoadRows: 12000, LastUpdateTime: epoch(time.Now()), ValidationState: "Validated",
+			TableState: "Table completed", AppliedInserts: 42, AppliedUpdates: 7,
+			FullLoadStartTime: epoch(time.Now().Add(-50 * time.Minute)), FullLoadEndTime: epoch(time.Now().Add(-20 * time.Minute))},
+	},
+}
+
+// taskTags holds the resource tags of tasks by ARN
+var taskTags = map[string]map[string]string{
+	"arn:aws:dms:us-east-1:123456789012:task:mock-task-1": {"team": "orders"},
+	"arn:aws:dms:us-east-1:123456789012:task:mock-task-3": {"team": "billing", "dms-manager:lint-ignore": "full-lob-mode"},
+}
+
+type MockInstance struct {
+	ReplicationInstanceArn        string `json:"ReplicationInstanceArn"`
+	ReplicationInstanceIdentifier string `json:"ReplicationInstanceIdentifier"`
+	ReplicationInstanceClass      string `json:"ReplicationInstanceClass"`
+	ReplicationInstanceStatus     string `json:"ReplicationInstanceStatus"`
+	EngineVersion                 string `json:"EngineVersion"`
+	AvailabilityZone              string `json:"AvailabilityZone"`
+	SecondaryAvailabilityZone     string `json:"SecondaryAvailabilityZone,omitempty"`
+	AllocatedStorage              int32  `json:"AllocatedStorage"`
+	MultiAZ                       bool   `json:"MultiAZ"`
+	PreferredMaintenanceWindow    string `json:"PreferredMaintenanceWindow"`
+	PendingModifiedValues         any    `json:"PendingModifiedValues,omitempty"`
+	InstanceCreateTime            int64  `json:"InstanceCreateTime"`
+}
+
+// instancesMu guards instances, whose statuses also change in the background
+var instancesMu sync.Mutex
+
+var instances = []*MockInstance{
+	{
+		ReplicationInstanceArn:        "arn:aws:dms:us-east-1:123456789012:rep:mock-instance",
+		ReplicationInstanceIdentifier: "mock-instance",
+		ReplicationInstanceClass:      "dms.t3.medium",
+		ReplicationInstanceStatus:     "available",
+		EngineVersion:                 "3.5.3",
+		AvailabilityZone:              "us-east-1a",
+		AllocatedStorage:              50,
+		PreferredMaintenanceWindow:    "sun:06:00-sun:06:30",
+		InstanceCreateTime:            epoch(time.Now().Add(-30 * 24 * time.Hour)),
+	},
+	{
+		ReplicationInstanceArn:        "arn:aws:dms:us-east-1:123456789012:rep:mock-instance-ha",
+		ReplicationInstanceIdentifier: "mock-instance-ha",
+		ReplicationInstanceClass:      "dms.r5.large",
+		ReplicationInstanceStatus:     "available",
+		EngineVersion:                 "3.5.2",
+		AvailabilityZone:              "us-east-1b",
+		SecondaryAvailabilityZone:     "us-east-1c",
+		AllocatedStorage:              200,
+		MultiAZ:                       true,
+		PreferredMaintenanceWindow:    "sat:04:00-sat:04:30",
+		PendingModifiedValues: map[string]any{
+			"ReplicationInstanceClass": "dms.r5.xlarge",
+			"EngineVersion":            "3.5.3",
+		},
+		InstanceCreateTime: epoch(time.Now().Add(-90 * 24 * time.Hour)),
 	},
 }
 
+type MockEndpoint struct {
+	EndpointArn        string `json:"EndpointArn"`
+	EndpointIdentifier string `json:"EndpointIdentifier"`
+	EndpointType       string `json:"EndpointType"`
+	EngineName         string `json:"EngineName"`
+	Status             string `json:"Status"`
+	ServerName         string `json:"ServerName"`
+	Port               int32  `json:"Port"`
+	DatabaseName       string `json:"DatabaseName"`
+}
+
+var endpoints = []MockEndpoint{
+	{
+		EndpointArn:        "arn:aws:dms:us-east-1:123456789012:endpoint:mock-source",
+		EndpointIdentifier: "mock-source",
+		EndpointType:       "SOURCE",
+		EngineName:         "postgres",
+		Status:             "active",
+		ServerName:         "source.example.com",
+		Port:               5432,
+		DatabaseName:       "app",
+	},
+	{
+		EndpointArn:        "arn:aws:dms:us-east-1:123456789012:endpoint:mock-target",
+		EndpointIdentifier: "mock-target",
+		EndpointType:       "TARGET",
+		EngineName:         "aurora-postgresql",
+		Status:             "active",
+		ServerName:         "target.example.com",
+		Port:               5432,
+		DatabaseName:       "app",
+	},
+}
+
+type MockConnection struct {
+	ReplicationInstanceArn        string `json:"ReplicationInstanceArn"`
+	ReplicationInstanceIdentifier string `json:"ReplicationInstanceIdentifier"`
+	EndpointArn                   string `json:"EndpointArn"`
+	EndpointIdentifier            string `json:"EndpointIdentifier"`
+	Status                        string `json:"Status"`
+	LastFailureMessage            string `json:"LastFailureMessage,omitempty"`
+}
+
+// connections holds connection test results keyed by instance and endpoint
+// ARN. Tests finish in the background, so access is guarded by connectionsMu.
+var (
+	connections   = map[string]*MockConnection{}
+	connectionsMu sync.Mutex
+)
+
+// failingConnections lists instance/endpoint pairs whose tests fail
+var failingConnections = map[string]string{
+	"arn:aws:dms:us-east-1:123456789012:rep:mock-instance-ha|arn:aws:dms:us-east-1:123456789012:endpoint:mock-target": "Connection timed out to target.example.com:5432",
+}
+
 func main() {
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		// Log request
@@ -143,6 +324,24 @@ func main() {
 			handleStopReplicationTask(w, r)
 		case strings.Contains(action, "DescribeTableStatistics"):
 			handleDescribeTableStatistics(w, r)
+		case strings.Contains(action, "DescribeReplicationInstances"):
+			handleDescribeReplicationInstances(w, r)
+		case strings.Contains(action, "RebootReplicationInstance"):
+			handleRebootReplicationInstance(w, r)
+		case strings.Contains(action, "ReloadTables"):
+			handleReloadTables(w, r)
+		case strings.Contains(action, "TestConnection"):
+			handleTestConnection(w, r)
+		case strings.Contains(action, "DescribeConnections"):
+			handleDescribeConnections(w, r)
+		case strings.Contains(action, "DescribeEndpoints"):
+			handleDescribeEndpoints(w, r)
+		case strings.Contains(action, "CreateReplicationTask"):
+			handleCreateReplicationTask(w, r)
+		case strings.Contains(action, "ModifyReplicationTask"):
+			handleModifyReplicationTask(w, r)
+		case strings.Contains(action, "ListTagsForResource"):
+			handleListTagsForResource(w, r)
 		default:
 			log.Printf("Unknown action: %s", action)
 			http.Error(w, "Unknown action", http.StatusBadRequest)
@@ -160,6 +359,8 @@ func handleDescribeReplicationTasks(w http.ResponseWriter, r *http.Request) {
 	// Parse request body
 	var req map[string]interface{}
 	json.NewDecoder(r.Body).Decode(&req)
+	tasksMu.Lock()
+	defer tasksMu.Unlock()
 
 	// Get filter if present
 	filters := req["Filters"]
@@ -202,14 +403,40 @@ func handleDescribeReplicationTasks(w http.ResponseWriter, r *http.Request) {
 func handleStartReplicationTask(w http.ResponseWriter, r *http.Request) {
 	var req map[string]interface{}
 	json.NewDecoder(r.Body).Decode(&req)
+	tasksMu.Lock()
+	defer tasksMu.Unlock()
 
 	arn := req["ReplicationTaskArn"].(string)
 
 	// Find and update task status
 	for _, task := range tasks {
 		if task.ReplicationTaskArn == arn {
+			if task.modifying {
+				w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]string{
+					"__type":  "InvalidResourceStateFault",
+					"message": "Task " + task.ReplicationTaskIdentifier + " is being modified",
+				})
+				return
+			}
 			task.Status = "starting"
 			task.ReplicationTaskStartDate = epoch(time.Now())
+			task.StopReason = ""
+			if task.ReplicationTaskStats != nil {
+				task.ReplicationTaskStats.StartDate = task.ReplicationTaskStartDate
+				task.ReplicationTaskStats.StopDate = 0
+			}
+
+			// Record CDC positions the way DMS reports them on the task
+			task.CdcStartPosition, _ = req["CdcStartPosition"].(string)
+			if start, ok := req["CdcStartTime"].(float64); ok {
+				task.CdcStartPosition = time.Unix(int64(start), 0).UTC().Format("2006-01-02T15:04:05")
+			}
+			task.CdcStopPosition, _ = req["CdcStopPosition"].(string)
+			if task.CdcStartPosition != "" || task.CdcStopPosition != "" {
+				log.Printf("⏱️  CDC of %s: start %q, stop %q", task.ReplicationTaskIdentifier, task.CdcStartPosition, task.CdcStopPosition)
+			}
 
 			response := map[string]interface{}{
 				"ReplicationTask": task,
@@ -230,6 +457,8 @@ func handleStartReplicationTask(w http.ResponseWriter, r *http.Request) {
 func handleStopReplicationTask(w http.ResponseWriter, r *http.Request) {
 	var req map[string]interface{}
 	json.NewDecoder(r.Body).Decode(&req)
+	tasksMu.Lock()
+	defer tasksMu.Unlock()
 
 	arn := req["ReplicationTaskArn"].(string)
 
@@ -237,6 +466,17 @@ func handleStopReplicationTask(w http.ResponseWriter, r *http.Request) {
 	for _, task := range tasks {
 		if task.ReplicationTaskArn == arn {
 			task.Status = "stopping"
+			task.StopReason = "STOP_REASON_USER_REQUESTED"
+			time.AfterFunc(3*time.Second, func() {
+				tasksMu.Lock()
+				defer tasksMu.Unlock()
+				if task.Status == "stopping" {
+					task.Status = "stopped"
+				}
+			})
+			if task.ReplicationTaskStats != nil {
+				task.ReplicationTaskStats.StopDate = epoch(time.Now())
+			}
 
 			response := map[string]interface{}{
 				"ReplicationTask": task,
@@ -266,15 +506,413 @@ func handleDescribeTableStatistics(w http.ResponseWriter, r *http.Request) {
 		stats = []MockTableStat{}
 	}
 
+	// Apply the schema-name, table-name and table-state filters
+	filtered := []MockTableStat{}
+	filters, _ := req["Filters"].([]interface{})
+	for _, stat := range stats {
+		keep := true
+		for _, f := range filters {
+			filter, _ := f.(map[string]interface{})
+			values, _ := filter["Values"].([]interface{})
+			var field string
+			switch filter["Name"] {
+			case "schema-name":
+				field = stat.SchemaName
+			case "table-name":
+				field = stat.TableName
+			case "table-state":
+				field = stat.TableState
+			}
+			match := false
+			for _, v := range values {
+				if s, _ := v.(string); strings.EqualFold(s, field) {
+					match = true
+				}
+			}
+			keep = keep && match
+		}
+		if keep {
+			filtered = append(filtered, stat)
+		}
+	}
+
 	response := map[string]interface{}{
-		"TableStatistics": stats,
+		"TableStatistics": filtered,
 	}
 
 	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
 	json.NewEncoder(w).Encode(response)
 }
 
+// filterValue returns the values of the first filter in a request, if any
+func filterValue(req map[string]interface{}) []string {
+	filterList, _ := req["Filters"].([]interface{})
+	if len(filterList) == 0 {
+		return nil
+	}
+	filter, _ := filterList[0].(map[string]interface{})
+	values, _ := filter["Values"].([]interface{})
+	var out []string
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func handleDescribeReplicationInstances(w http.ResponseWriter, r *http.Request) {
+	var req map[string]interface{}
+	json.NewDecoder(r.Body).Decode(&req)
+	instancesMu.Lock()
+	defer instancesMu.Unlock()
+
+	values := filterValue(req)
+	result := []*MockInstance{}
+	for _, inst := range instances {
+		if values == nil || inst.ReplicationInstanceArn == values[0] || inst.ReplicationInstanceIdentifier == values[0] {
+			result = append(result, inst)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	json.NewEncoder(w).Encode(map[string]interface{}{"ReplicationInstances": result})
+}
+
+func handleRebootReplicationInstance(w http.ResponseWriter, r *http.Request) {
+	var req map[string]interface{}
+	json.NewDecoder(r.Body).Decode(&req)
+	instancesMu.Lock()
+	defer instancesMu.Unlock()
+
+	arn, _ := req["ReplicationInstanceArn"].(string)
+	failover, _ := req["ForceFailover"].(bool)
+
+	for _, inst := range instances {
+		if inst.ReplicationInstanceArn != arn {
+			continue
+		}
+
+		if failover && !inst.MultiAZ {
+			w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{
+				"__type":  "InvalidParameterCombinationException",
+				"message": "ForceFailover requires a Multi-AZ replication instance",
+			})
+			return
+		}
+
+		inst.ReplicationInstanceStatus = "rebooting"
+		time.AfterFunc(5*time.Second, func() {
+			instancesMu.Lock()
+			defer instancesMu.Unlock()
+			if failover {
+				inst.AvailabilityZone, inst.SecondaryAvailabilityZone = inst.SecondaryAvailabilityZone, inst.AvailabilityZone
+			}
+			inst.ReplicationInstanceStatus = "available"
+		})
+
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		json.NewEncoder(w).Encode(map[string]interface{}{"ReplicationInstance": inst})
+
+		log.Printf("🔄 Rebooting instance: %s (failover: %t)", inst.ReplicationInstanceIdentifier, failover)
+		return
+	}
+
+	http.Error(w, "Instance not found", http.StatusNotFound)
+}
+
+func handleDescribeEndpoints(w http.ResponseWriter, r *http.Request) {
+	var req map[string]interface{}
+	json.NewDecoder(r.Body).Decode(&req)
+
+	values := filterValue(req)
+	result := []MockEndpoint{}
+	for _, ep := range endpoints {
+		if values == nil || ep.EndpointArn == values[0] || ep.EndpointIdentifier == values[0] {
+			result = append(result, ep)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	json.NewEncoder(w).Encode(map[string]interface{}{"Endpoints": result})
+}
+
+func handleCreateReplicationTask(w http.ResponseWriter, r *http.Request) {
+	var req map[string]interface{}
+	json.NewDecoder(r.Body).Decode(&req)
+	tasksMu.Lock()
+	defer tasksMu.Unlock()
+
+	id, _ := req["ReplicationTaskIdentifier"].(string)
+	if _, exists := tasks[id]; exists {
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"__type":  "ResourceAlreadyExistsFault",
+			"message": "Task " + id + " already exists",
+		})
+		return
+	}
+
+	str := func(key string) string {
+		v, _ := req[key].(string)
+		return v
+	}
+	task := &MockTask{
+		ReplicationTaskArn:          "arn:aws:dms:us-east-1:123456789012:task:" + id,
+		ReplicationTaskIdentifier:   id,
+		Status:                      "creating",
+		ReplicationInstanceArn:      str("ReplicationInstanceArn"),
+		SourceEndpointArn:           str("SourceEndpointArn"),
+		TargetEndpointArn:           str("TargetEndpointArn"),
+		MigrationType:               str("MigrationType"),
+		TableMappings:               str("TableMappings"),
+		ReplicationTaskSettings:     str("ReplicationTaskSettings"),
+		ReplicationTaskCreationDate: epoch(time.Now()),
+	}
+	tasks[id] = task
+
+	// Created tasks become ready after a few seconds
+	time.AfterFunc(5*time.Second, func() {
+		tasksMu.Lock()
+		defer tasksMu.Unlock()
+		task.Status = "ready"
+	})
+
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	json.NewEncoder(w).Encode(map[string]interface{}{"ReplicationTask": task})
+
+	log.Printf("✨ Created task: %s", id)
+}
+
 func epoch(t time.Time) int64 {
 	// AWS SDK expects Unix epoch in seconds as int64
 	return t.Unix()
 }
+
+// filterValues returns the values of every filter in a request by name
+func filterValues(req map[string]interface{}) map[string][]string {
+	out := map[string][]string{}
+	filterList, _ := req["Filters"].([]interface{})
+	for _, f := range filterList {
+		filter, _ := f.(map[string]interface{})
+		name, _ := filter["Name"].(string)
+		values, _ := filter["Values"].([]interface{})
+		for _, v := range values {
+			if s, ok := v.(string); ok {
+				out[name] = append(out[name], s)
+			}
+		}
+	}
+	return out
+}
+
+func handleReloadTables(w http.ResponseWriter, r *http.Request) {
+	var req map[string]interface{}
+	json.NewDecoder(r.Body).Decode(&req)
+
+	arn, _ := req["ReplicationTaskArn"].(string)
+	option, _ := req["ReloadOption"].(string)
+	toReload, _ := req["TablesToReload"].([]interface{})
+
+	stats, ok := tableStats[arn]
+	if !ok {
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"__type":  "InvalidResourceStateFault",
+			"message": "Task has no tables to reload",
+		})
+		return
+	}
+
+	// Reloaded tables start over; revalidated ones only wait for validation
+	for _, t := range toReload {
+		table, _ := t.(map[string]interface{})
+		for i := range stats {
+			if stats[i].SchemaName == table["SchemaName"] && stats[i].TableName == table["TableName"] {
+				if option != "validate-only" {
+					stats[i].FullLoadRows = 0
+					stats[i].FullLoadErrorRows = 0
+					stats[i].FullLoadReloaded = true
+					stats[i].TableState = "Table loading"
+					stats[i].FullLoadStartTime = epoch(time.Now())
+					stats[i].FullLoadEndTime = 0
+				}
+				stats[i].ValidationState = "Pending records"
+				stats[i].LastUpdateTime = epoch(time.Now())
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	json.NewEncoder(w).Encode(map[string]interface{}{"ReplicationTaskArn": arn})
+
+	log.Printf("🔁 Reloading %d table(s) of %s (%s)", len(toReload), arn, option)
+}
+
+func handleTestConnection(w http.ResponseWriter, r *http.Request) {
+	var req map[string]interface{}
+	json.NewDecoder(r.Body).Decode(&req)
+
+	instanceArn, _ := req["ReplicationInstanceArn"].(string)
+	endpointArn, _ := req["EndpointArn"].(string)
+
+	var instance *MockInstance
+	for _, inst := range instances {
+		if inst.ReplicationInstanceArn == instanceArn {
+			instance = inst
+		}
+	}
+	var endpoint *MockEndpoint
+	for i := range endpoints {
+		if endpoints[i].EndpointArn == endpointArn {
+			endpoint = &endpoints[i]
+		}
+	}
+	if instance == nil || endpoint == nil {
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"__type":  "ResourceNotFoundFault",
+			"message": "Replication instance or endpoint not found",
+		})
+		return
+	}
+
+	key := instanceArn + "|" + endpointArn
+	conn := &MockConnection{
+		ReplicationInstanceArn:        instanceArn,
+		ReplicationInstanceIdentifier: instance.ReplicationInstanceIdentifier,
+		EndpointArn:                   endpointArn,
+		EndpointIdentifier:            endpoint.EndpointIdentifier,
+		Status:                        "testing",
+	}
+
+	connectionsMu.Lock()
+	connections[key] = conn
+	response := *conn
+	connectionsMu.Unlock()
+
+	// Tests take a few seconds, like the real service
+	time.AfterFunc(3*time.Second, func() {
+		connectionsMu.Lock()
+		defer connectionsMu.Unlock()
+		if msg, ok := failingConnections[key]; ok {
+			conn.Status = "failed"
+			conn.LastFailureMessage = msg
+		} else {
+			conn.Status = "successful"
+		}
+	})
+
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	json.NewEncoder(w).Encode(map[string]interface{}{"Connection": response})
+
+	log.Printf("🔌 Testing connection: %s -> %s", instance.ReplicationInstanceIdentifier, endpoint.EndpointIdentifier)
+}
+
+func handleDescribeConnections(w http.ResponseWriter, r *http.Request) {
+	var req map[string]interface{}
+	json.NewDecoder(r.Body).Decode(&req)
+
+	filters := filterValues(req)
+	matches := func(name, value string) bool {
+		values, ok := filters[name]
+		if !ok {
+			return true
+		}
+		for _, v := range values {
+			if v == value {
+				return true
+			}
+		}
+		return false
+	}
+
+	connectionsMu.Lock()
+	result := []MockConnection{}
+	for _, conn := range connections {
+		if matches("replication-instance-arn", conn.ReplicationInstanceArn) && matches("endpoint-arn", conn.EndpointArn) {
+			result = append(result, *conn)
+		}
+	}
+	connectionsMu.Unlock()
+
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	json.NewEncoder(w).Encode(map[string]interface{}{"Connections": result})
+}
+
+func handleModifyReplicationTask(w http.ResponseWriter, r *http.Request) {
+	var req map[string]interface{}
+	json.NewDecoder(r.Body).Decode(&req)
+	tasksMu.Lock()
+	defer tasksMu.Unlock()
+
+	arn, _ := req["ReplicationTaskArn"].(string)
+	for _, task := range tasks {
+		if task.ReplicationTaskArn != arn {
+			continue
+		}
+
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		if task.Status != "stopped" && task.Status != "ready" && task.Status != "failed" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{
+				"__type":  "InvalidResourceStateFault",
+				"message": "Task " + task.ReplicationTaskIdentifier + " is " + task.Status + ", stop it before modifying it",
+			})
+			return
+		}
+
+		// Like DMS, the task keeps its status for a moment before it turns
+		// modifying, and shows the new settings once the modification is done
+		settings, hasSettings := req["ReplicationTaskSettings"].(string)
+		previous := task.Status
+		task.modifying = true
+		time.AfterFunc(time.Second, func() {
+			tasksMu.Lock()
+			defer tasksMu.Unlock()
+			task.Status = "modifying"
+		})
+		time.AfterFunc(4*time.Second, func() {
+			tasksMu.Lock()
+			defer tasksMu.Unlock()
+			if hasSettings {
+				task.ReplicationTaskSettings = settings
+			}
+			task.Status = previous
+			task.modifying = false
+		})
+
+		json.NewEncoder(w).Encode(map[string]interface{}{"ReplicationTask": task})
+
+		log.Printf("🛠️  Modifying task: %s", task.ReplicationTaskIdentifier)
+		return
+	}
+
+	http.Error(w, "Task not found", http.StatusNotFound)
+}
+
+func handleListTagsForResource(w http.ResponseWriter, r *http.Request) {
+	var req map[string]interface{}
+	json.NewDecoder(r.Body).Decode(&req)
+
+	arns, _ := req["ResourceArnList"].([]interface{})
+	if arn, ok := req["ResourceArn"].(string); ok {
+		arns = append(arns, arn)
+	}
+
+	tagList := []map[string]string{}
+	for _, a := range arns {
+		arn, _ := a.(string)
+		for key, value := range taskTags[arn] {
+			tagList = append(tagList, map[string]string{"Key": key, "Value": value, "ResourceArn": arn})
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	json.NewEncoder(w).Encode(map[string]interface{}{"TagList": tagList})
+}